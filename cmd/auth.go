@@ -0,0 +1,376 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	pgperrors "golang.org/x/crypto/openpgp/errors"
+	"golang.org/x/crypto/openpgp/packet"
+	"golang.org/x/crypto/openpgp/s2k"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// passphraseEnv is read instead of prompting for the passphrase, e.g. in CI.
+const passphraseEnv = "OSS_CONTRIBUTION_CHECKER_PASSPHRASE"
+
+// errCannotPrompt is returned by readPassphrase without a terminal and
+// without passphraseEnv.
+var errCannotPrompt = fmt.Errorf("cannot prompt for a passphrase, set %s", passphraseEnv)
+
+var authParams struct {
+	recipientKey string
+}
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "manage the encrypted github token",
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "store a github token encrypted in the user config dir",
+	Long: `Store a github token encrypted in the user config dir.
+
+The token is read from stdin, or prompted for when stdin is a terminal. It is
+encrypted with a passphrase, or to the public key given by --recipient-key.
+Decrypting a token stored for a public key needs the private key given by
+--secret-key.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := readLoginToken()
+		if err != nil {
+			return err
+		}
+		path, err := tokenStorePath()
+		if err != nil {
+			return err
+		}
+		if err := writeTokenStore(path, token); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "stored encrypted github token for %s in %s\n", params.host, path)
+		return nil
+	},
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "show where a github token would be read from",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := tokenStorePath()
+		if err != nil {
+			return err
+		}
+		for _, src := range tokenSources() {
+			switch src.name {
+			case "--token-stdin":
+				continue
+			case "encrypted token store":
+				fmt.Printf("%-24s %s\n", src.name, tokenStoreStatus(path))
+				continue
+			}
+			token, err := src.read()
+			state := "not found"
+			switch {
+			case err != nil:
+				state = "error: " + err.Error()
+			case strings.TrimSpace(token) != "":
+				state = "found"
+			}
+			fmt.Printf("%-24s %s\n", src.name, state)
+		}
+		return nil
+	},
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "remove the encrypted github token",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := tokenStorePath()
+		if err != nil {
+			return err
+		}
+		err = os.Remove(path)
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no token stored for %s", params.host)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "removed %s\n", path)
+		return nil
+	},
+}
+
+// tokenStorePath returns the path of the encrypted token of the host.
+func tokenStorePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oss-contribution-checker", params.host+".token.asc"), nil
+}
+
+// readTokenStore decrypts the stored token. It returns an empty token if
+// nothing is stored.
+func readTokenStore() (string, error) {
+	path, err := tokenStorePath()
+	if err != nil {
+		return "", nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := enforceTokenStoreMode(path); err != nil {
+		return "", err
+	}
+
+	var keyring openpgp.EntityList
+	if params.secretKey != "" {
+		keyring, err = readKeyRing(params.secretKey)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	block, err := armor.Decode(bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	prompted := false
+	md, err := openpgp.ReadMessage(block.Body, keyring, func(keys []openpgp.Key, symmetric bool) ([]byte, error) {
+		if prompted {
+			return nil, errors.New("wrong passphrase")
+		}
+		prompted = true
+		if !symmetric && len(keys) == 0 {
+			return nil, errors.New("token is encrypted to a public key, pass the private key with --secret-key")
+		}
+		passphrase, err := readPassphrase("passphrase for " + path + ": ")
+		if err != nil {
+			return nil, err
+		}
+		if symmetric {
+			return passphrase, nil
+		}
+		for _, k := range keys {
+			if k.PrivateKey != nil && k.PrivateKey.Encrypted {
+				// errors are reported by ReadMessage as a missing key
+				_ = k.PrivateKey.Decrypt(passphrase)
+			}
+		}
+		return nil, nil
+	}, nil)
+	if err == pgperrors.ErrKeyIncorrect && params.secretKey == "" {
+		return "", errors.New("token is encrypted to a public key, pass the private key with --secret-key")
+	}
+	if err != nil {
+		return "", err
+	}
+	token, err := ioutil.ReadAll(md.UnverifiedBody)
+	if err != nil {
+		return "", err
+	}
+	return string(token), nil
+}
+
+// writeTokenStore encrypts the token and writes it with 0600 permissions.
+func writeTokenStore(path, token string) error {
+	var buf bytes.Buffer
+	aw, err := armor.Encode(&buf, "PGP MESSAGE", nil)
+	if err != nil {
+		return err
+	}
+
+	hints := &openpgp.FileHints{IsBinary: true}
+	var w io.WriteCloser
+	if authParams.recipientKey != "" {
+		recipients, err := readKeyRing(authParams.recipientKey)
+		if err != nil {
+			return err
+		}
+		// Keys without hash preferences make Encrypt fall back to
+		// RIPEMD160, which is not compiled in. No signature is made, so
+		// the choice does not matter.
+		sha256ID, _ := s2k.HashToHashId(crypto.SHA256)
+		for _, e := range recipients {
+			for _, id := range e.Identities {
+				if id.SelfSignature != nil && len(id.SelfSignature.PreferredHash) == 0 {
+					id.SelfSignature.PreferredHash = []uint8{sha256ID}
+				}
+			}
+		}
+		w, err = openpgp.Encrypt(aw, recipients, nil, hints, nil)
+		if err != nil {
+			return err
+		}
+	} else {
+		passphrase, err := readPassphrase("new passphrase: ")
+		if err != nil {
+			return err
+		}
+		if os.Getenv(passphraseEnv) == "" {
+			again, err := readPassphrase("repeat passphrase: ")
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(passphrase, again) {
+				return errors.New("passphrases do not match")
+			}
+		}
+		if len(passphrase) == 0 {
+			return errors.New("empty passphrase")
+		}
+		w, err = openpgp.SymmetricallyEncrypt(aw, passphrase, hints, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(w, token); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := aw.Close(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file
+	return os.Chmod(path, 0600)
+}
+
+// enforceTokenStoreMode resets the permissions of the token store to 0600.
+func enforceTokenStoreMode(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if fi.Mode().Perm() == 0600 {
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "warning: %s had mode %s, resetting to 0600\n", path, fi.Mode().Perm())
+	return os.Chmod(path, 0600)
+}
+
+// tokenStoreStatus describes the stored token without decrypting it.
+func tokenStoreStatus(path string) string {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "not found"
+	}
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	block, err := armor.Decode(bytes.NewReader(b))
+	if err != nil {
+		return "error: " + err.Error()
+	}
+
+	enc := "unknown encryption"
+	p, err := packet.Read(block.Body)
+	if err == nil {
+		switch p := p.(type) {
+		case *packet.SymmetricKeyEncrypted:
+			enc = "passphrase"
+		case *packet.EncryptedKey:
+			enc = fmt.Sprintf("public key %X", p.KeyId)
+		}
+	}
+	return fmt.Sprintf("found (%s, %s)", enc, path)
+}
+
+// readKeyRing reads an armored or binary OpenPGP key ring.
+func readKeyRing(path string) (openpgp.EntityList, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if el, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(b)); err == nil {
+		return el, nil
+	}
+	el, err := openpgp.ReadKeyRing(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key ring %s: %w", path, err)
+	}
+	return el, nil
+}
+
+// readLoginToken reads the token to store from stdin.
+func readLoginToken() (string, error) {
+	var token string
+	if terminal.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprint(os.Stderr, "github token: ")
+		b, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		token = string(b)
+	} else {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		token = line
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errors.New("empty token")
+	}
+	return token, nil
+}
+
+// readPassphrase reads a passphrase from the environment or the terminal.
+func readPassphrase(prompt string) ([]byte, error) {
+	if p := os.Getenv(passphraseEnv); p != "" {
+		return []byte(p), nil
+	}
+
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		tty = os.Stdin
+	} else {
+		defer tty.Close()
+	}
+	if !terminal.IsTerminal(int(tty.Fd())) {
+		return nil, errCannotPrompt
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	defer fmt.Fprintln(os.Stderr)
+	return terminal.ReadPassword(int(tty.Fd()))
+}
+
+func init() {
+	authLoginCmd.Flags().StringVar(&authParams.recipientKey, "recipient-key", "", "encrypt to this OpenPGP public key file instead of a passphrase")
+
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authStatusCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	rootCmd.AddCommand(authCmd)
+}
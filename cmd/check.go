@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+)
+
+var checkParams struct {
+	rules  []string
+	format string
+}
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "check threshold rules against your contributions",
+	Long: `Check threshold rules against your contributions, e.g. in CI or cron jobs.
+
+A rule is "<metric> [in <days>d] <op> <value>":
+
+  check --rule "merged_prs in 365d >= 3" --rule "repos >= 2"
+
+Metrics: ` + strings.Join(contrib.MetricNames(), ", ") + `.
+repos counts the distinct repositories of your issues, PRs and reviews,
+the others count contributions. "in <days>d" counts merged_prs by when
+they were merged, reviews by when you first reviewed and the others by
+when they were created.
+Operators: >=, <=, >, <, ==, !=.
+
+Exit codes:
+  0  all rules passed
+  1  error, e.g. an invalid rule or a failed fetch
+  2  a rule failed`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(checkParams.rules) == 0 {
+			return errors.New("no rule is specified")
+		}
+		if checkParams.format != "text" && checkParams.format != "json" {
+			return fmt.Errorf("unknown format: %s (valid: text, json)", checkParams.format)
+		}
+		var rules []contrib.Rule
+		for _, s := range checkParams.rules {
+			r, err := contrib.ParseRule(s)
+			if err != nil {
+				return err
+			}
+			if r.Metric == "reviews" {
+				params.reviews = true
+			}
+			rules = append(rules, r)
+		}
+
+		contributions, err := retrieveData()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		passed := true
+		results := make([]contrib.RuleResult, 0, len(rules))
+		for _, r := range rules {
+			result := r.Evaluate(contributions, now)
+			passed = passed && result.Passed
+			results = append(results, result)
+		}
+
+		if checkParams.format == "json" {
+			e := json.NewEncoder(os.Stdout)
+			e.SetIndent("", "  ")
+			e.SetEscapeHTML(false)
+			err = e.Encode(struct {
+				Passed bool                 `json:"passed"`
+				Rules  []contrib.RuleResult `json:"rules"`
+			}{passed, results})
+		} else {
+			err = printCheckResults(results)
+		}
+		if err != nil {
+			return err
+		}
+		if !passed {
+			return exitWith(cmd, 2)
+		}
+		return nil
+	},
+}
+
+func printCheckResults(results []contrib.RuleResult) error {
+	theme, err := contrib.LoadTheme(params.theme, termenv.EnvColorProfile())
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		status := termenv.String("FAIL").Foreground(theme.Red)
+		if r.Passed {
+			status = termenv.String("PASS").Foreground(theme.Green)
+		}
+		if _, err := fmt.Printf("%s  %s (actual: %d)\n", status, r.Rule, r.Actual); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	addSourceFlags(checkCmd)
+	addCachedFlags(checkCmd)
+	addRedactFlags(checkCmd)
+	checkCmd.Flags().StringArrayVar(&checkParams.rules, "rule", nil, `rules like "merged_prs in 365d >= 3"`)
+	checkCmd.Flags().StringVar(&checkParams.format, "format", "text", "output format: text, json")
+	addStyleFlags(checkCmd)
+	rootCmd.AddCommand(checkCmd)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckExitCodes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "check")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	report := writeTestReport(t, dir, testContributions())
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{name: "passed", args: []string{"--from", report, "--rule", "merged_prs >= 1", "--rule", "repos == 2"}, wantCode: 0, wantOut: "PASS  repos == 2"},
+		{name: "failed", args: []string{"--from", report, "--rule", "merged_prs >= 1", "--rule", "prs >= 5"}, wantCode: 2, wantOut: "FAIL  prs >= 5 (actual: 2)"},
+		{name: "json", args: []string{"--from", report, "--rule", "prs >= 5", "--format", "json"}, wantCode: 2, wantOut: `"passed": false`},
+		{name: "invalid rule", args: []string{"--from", report, "--rule", "stars >= 1"}, wantCode: 1},
+		{name: "no rule", args: []string{"--from", report}, wantCode: 1},
+		{name: "missing report", args: []string{"--from", filepath.Join(dir, "missing.json"), "--rule", "prs >= 1"}, wantCode: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, _, err := executeCommand(t, append([]string{"check"}, tt.args...)...)
+			// Execute exits with 1 on errors which are not an exitCode
+			code := 0
+			var ec exitCode
+			switch {
+			case errors.As(err, &ec):
+				code = int(ec)
+			case err != nil:
+				code = 1
+			}
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d (error %v), want %d", code, err, tt.wantCode)
+			}
+			if !strings.Contains(out, tt.wantOut) {
+				t.Errorf("stdout = %q, want it to contain %q", out, tt.wantOut)
+			}
+		})
+	}
+}
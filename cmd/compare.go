@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+)
+
+var compareParams struct {
+	accounts []string
+	since    []string
+	until    []string
+	format   string
+}
+
+var compareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "compare two periods or two accounts side by side",
+	Long: `Compare the summary columns of two periods or two accounts side by side with
+the absolute and the percent change, and list the repos which are new in,
+dropped from or grew the most in the second one with their shares of issues
+and pull requests.
+
+  compare --account alice --since 2025-01-01 --until 2025-06-30 --since 2025-07-01 --until 2025-12-31
+  compare --accounts alice,bob [--since 2025-01-01 --until 2025-12-31]
+
+Accounts are compared by their github contributions, so --source local and
+--ledger cannot be used with --accounts.
+
+Days are YYYY-MM-DD in UTC, until is included.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch compareParams.format {
+		case "table", "json", "markdown":
+		default:
+			return fmt.Errorf("unknown format: %s (valid: table, json, markdown)", compareParams.format)
+		}
+		if len(compareParams.since) != len(compareParams.until) {
+			return errors.New("--since and --until must be given the same number of times")
+		}
+		var periods []contrib.Period
+		for i := range compareParams.since {
+			p, err := contrib.ParsePeriod(compareParams.since[i], compareParams.until[i])
+			if err != nil {
+				return err
+			}
+			periods = append(periods, p)
+		}
+
+		var labels [2]string
+		var data [2][]contrib.Contribution
+		switch {
+		case len(compareParams.accounts) > 0:
+			if len(compareParams.accounts) != 2 {
+				return errors.New("--accounts needs two accounts")
+			}
+			if params.from != "" {
+				return errors.New("--from holds the contributions of one account, compare periods instead")
+			}
+			if len(periods) > 1 {
+				return errors.New("comparing accounts takes at most one period")
+			}
+			// local commits and ledger entries are not per account, both
+			// accounts would get the same ones
+			sources, err := parseSources(params.sources)
+			if err != nil {
+				return err
+			}
+			if _, ok := sources["local"]; ok || params.ledger != "" {
+				return errors.New("--accounts compares github accounts, --source local and --ledger cannot be used with it")
+			}
+			account := params.account
+			for i, a := range compareParams.accounts {
+				params.account = a
+				contributions, err := retrieveData()
+				if err != nil {
+					return err
+				}
+				if len(periods) == 1 {
+					contributions = periods[0].Filter(contributions)
+				}
+				labels[i], data[i] = a, contributions
+			}
+			// the report read for the second account is not the one of
+			// --account
+			params.account, loadedReport = account, nil
+		case len(periods) == 2:
+			contributions, err := retrieveData()
+			if err != nil {
+				return err
+			}
+			for i, p := range periods {
+				labels[i], data[i] = p.String(), p.Filter(contributions)
+			}
+		default:
+			return errors.New("give two periods with --since and --until, or two accounts with --accounts")
+		}
+
+		c := contrib.Compare(labels, data[0], data[1])
+		if compareParams.format == "json" {
+			e := json.NewEncoder(os.Stdout)
+			e.SetIndent("", "  ")
+			return e.Encode(c)
+		}
+		r, err := newTableRenderer()
+		if err != nil {
+			return err
+		}
+		return r.RenderComparison(os.Stdout, c, compareParams.format == "markdown")
+	},
+}
+
+func init() {
+	addSourceFlags(compareCmd)
+	addCachedFlags(compareCmd)
+	addRedactFlags(compareCmd)
+	compareCmd.Flags().StringSliceVar(&compareParams.accounts, "accounts", nil, "two github accounts to compare")
+	compareCmd.Flags().StringSliceVar(&compareParams.since, "since", nil, "first day of a period, YYYY-MM-DD (twice to compare periods)")
+	compareCmd.Flags().StringSliceVar(&compareParams.until, "until", nil, "last day of a period, YYYY-MM-DD (twice to compare periods)")
+	compareCmd.Flags().StringVar(&compareParams.format, "format", "table", "output format: table, json, markdown")
+	addStyleFlags(compareCmd)
+	compareCmd.Flags().UintVar(&params.width, "width", 0, "max output width")
+	rootCmd.AddCommand(compareCmd)
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+// envPrefix is prepended to the upper-cased flag name to get the environment
+// variable overriding the flag's config value.
+const envPrefix = "OSS_CONTRIBUTION_CHECKER_"
+
+// Config is the persistent configuration file. Defaults and views map flag
+// names to values. Kinds maps kinds to the labels classified as them, and
+// replaces contrib.DefaultLabelKinds if set. Scores replaces single weights
+// of contrib.DefaultScoreWeights.
+type Config struct {
+	Defaults map[string]interface{}            `yaml:"defaults"`
+	Views    map[string]map[string]interface{} `yaml:"views"`
+	Kinds    map[string][]string               `yaml:"kinds"`
+	Scores   contrib.ScoreWeights              `yaml:"scores"`
+}
+
+var config Config
+
+// defaultConfigPath returns $XDG_CONFIG_HOME/oss-contribution-checker/config.yaml.
+func defaultConfigPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "oss-contribution-checker", "config.yaml")
+}
+
+// loadConfig reads the config file. A missing file is only an error if the
+// path was given explicitly.
+func loadConfig(path string, explicit bool) (Config, error) {
+	var c Config
+	if path == "" {
+		return c, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && !explicit {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	if err := yaml.UnmarshalStrict(b, &c); err != nil {
+		return c, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if err := c.Scores.Validate(); err != nil {
+		return c, fmt.Errorf("config %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// classifier returns the kind classifier using the label mappings of the
+// config file.
+func classifier() contrib.Classifier {
+	if len(config.Kinds) > 0 {
+		return contrib.Classifier{LabelKinds: config.Kinds}
+	}
+	return contrib.Classifier{LabelKinds: contrib.DefaultLabelKinds}
+}
+
+// scoreWeights returns the default score weights with the ones of the
+// config file replaced.
+func scoreWeights() contrib.ScoreWeights {
+	return contrib.DefaultScoreWeights().With(config.Scores)
+}
+
+// applyConfig fills every flag which was not set on the command line from,
+// in order of precedence, the environment, the selected view and the config
+// defaults.
+func applyConfig(cmd *cobra.Command) error {
+	c, err := loadConfig(params.config, cmd.Flags().Changed("config"))
+	if err != nil {
+		return err
+	}
+	config = c
+
+	var view map[string]interface{}
+	if params.view != "" {
+		var ok bool
+		view, ok = config.Views[params.view]
+		if !ok {
+			return fmt.Errorf("unknown view: %s (valid: %s)", params.view, strings.Join(viewNames(), ", "))
+		}
+	}
+	for _, m := range []map[string]interface{}{config.Defaults, view} {
+		for k := range m {
+			if !isKnownFlag(cmd.Root(), k) {
+				return fmt.Errorf("unknown option in config: %s", k)
+			}
+		}
+	}
+
+	var ferr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if ferr != nil || f.Changed || f.Name == "help" || f.Name == "config" || f.Name == "view" {
+			return
+		}
+		env := envPrefix + strings.ToUpper(strings.Replace(f.Name, "-", "_", -1))
+		if v, ok := os.LookupEnv(env); ok {
+			ferr = setFlagValue(f, v, env)
+			return
+		}
+		if v, ok := view[f.Name]; ok {
+			ferr = setConfigValue(f, v, "view "+params.view)
+			return
+		}
+		if v, ok := config.Defaults[f.Name]; ok {
+			ferr = setConfigValue(f, v, "config")
+		}
+	})
+	return ferr
+}
+
+// setFlagValue sets the value without marking the flag as changed, so that
+// it still reads as "not given on the command line".
+func setFlagValue(f *pflag.Flag, v, from string) error {
+	if err := f.Value.Set(v); err != nil {
+		return fmt.Errorf("invalid value %q for %s from %s: %w", v, f.Name, from, err)
+	}
+	return nil
+}
+
+// setConfigValue sets a YAML value. The elements of a list are set one by
+// one for string array flags, whose values may contain commas.
+func setConfigValue(f *pflag.Flag, v interface{}, from string) error {
+	list, ok := v.([]interface{})
+	if !ok || f.Value.Type() != "stringArray" {
+		return setFlagValue(f, configValueString(v), from)
+	}
+	for _, e := range list {
+		if err := setFlagValue(f, fmt.Sprint(e), from); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configValueString converts a YAML value to its flag string representation.
+func configValueString(v interface{}) string {
+	switch v := v.(type) {
+	case []interface{}:
+		s := make([]string, len(v))
+		for i, e := range v {
+			s[i] = fmt.Sprint(e)
+		}
+		return strings.Join(s, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// isKnownFlag returns true if c or any of its subcommands has the flag.
+// Config defaults are shared by all commands, so they may set flags the
+// running command does not have.
+func isKnownFlag(c *cobra.Command, name string) bool {
+	if c.Flags().Lookup(name) != nil || c.PersistentFlags().Lookup(name) != nil {
+		return true
+	}
+	for _, sub := range c.Commands() {
+		if isKnownFlag(sub, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func viewNames() []string {
+	var names []string
+	for k := range config.Views {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
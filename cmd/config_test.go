@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestApplyConfig(t *testing.T) {
+	saved, savedConfig := params, config
+	defer func() { params, config = saved, savedConfig }()
+
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.yaml")
+	err = ioutil.WriteFile(path, []byte(`defaults:
+  account: from-defaults
+  host: defaults.example.com
+  source: local
+  rule: ["repos >= 1, really", "reviews >= 2"]
+views:
+  team:
+    account: from-view
+    host: view.example.com
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		env   map[string]string
+		want  [3]string
+		rules []string
+	}{
+		{
+			name:  "defaults",
+			want:  [3]string{"from-defaults", "defaults.example.com", "local"},
+			rules: []string{"repos >= 1, really", "reviews >= 2"},
+		},
+		{
+			name:  "view over defaults",
+			args:  []string{"--view", "team"},
+			want:  [3]string{"from-view", "view.example.com", "local"},
+			rules: []string{"repos >= 1, really", "reviews >= 2"},
+		},
+		{
+			name:  "environment over view",
+			args:  []string{"--view", "team"},
+			env:   map[string]string{envPrefix + "ACCOUNT": "from-env"},
+			want:  [3]string{"from-env", "view.example.com", "local"},
+			rules: []string{"repos >= 1, really", "reviews >= 2"},
+		},
+		{
+			name:  "command line over environment",
+			args:  []string{"--view", "team", "--account", "from-flag", "--rule", "commits >= 1"},
+			env:   map[string]string{envPrefix + "ACCOUNT": "from-env"},
+			want:  [3]string{"from-flag", "view.example.com", "local"},
+			rules: []string{"commits >= 1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+			params = saved
+			var rules []string
+			c := &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+			c.Flags().StringVar(&params.config, "config", path, "")
+			c.Flags().StringVar(&params.view, "view", "", "")
+			c.Flags().StringVar(&params.account, "account", "", "")
+			c.Flags().StringVar(&params.host, "host", "github.com", "")
+			c.Flags().StringVar(&params.sources, "source", "github", "")
+			c.Flags().StringArrayVar(&rules, "rule", nil, "")
+			if err := c.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := applyConfig(c); err != nil {
+				t.Fatal(err)
+			}
+			if got := [3]string{params.account, params.host, params.sources}; got != tt.want {
+				t.Errorf("account, host, source = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(rules, tt.rules) {
+				t.Errorf("rule = %q, want %q", rules, tt.rules)
+			}
+		})
+	}
+}
+
+func TestApplyConfigErrors(t *testing.T) {
+	saved, savedConfig := params, config
+	defer func() { params, config = saved, savedConfig }()
+
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name   string
+		config string
+		args   []string
+		want   string
+	}{
+		{name: "unknown view", config: "views: {team: {}}\n", args: []string{"--view", "other"}, want: "unknown view: other (valid: team)"},
+		{name: "unknown option", config: "defaults: {acount: alice}\n", want: "unknown option in config: acount"},
+		{name: "invalid value", config: "defaults: {width: wide}\n", want: `invalid value "wide" for width from config`},
+		{name: "missing explicit config", args: []string{"--config", filepath.Join(dir, "missing.yaml")}, want: "no such file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params = saved
+			path := filepath.Join(dir, "config.yaml")
+			if err := ioutil.WriteFile(path, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			c := &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+			c.Flags().StringVar(&params.config, "config", path, "")
+			c.Flags().StringVar(&params.view, "view", "", "")
+			c.Flags().StringVar(&params.account, "account", "", "")
+			c.Flags().UintVar(&params.width, "width", 0, "")
+			if err := c.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := applyConfig(c)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("applyConfig() = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+)
+
+var diffParams struct {
+	format string
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff old.json new.json",
+	Short: "show what changed between two reports written by export",
+	Long: `Show what changed between two JSON reports written by "export --format json":
+the issues and PRs which were opened, merged, closed without merging,
+reopened or retitled, other items which were added, and the year and repo
+summary rows whose counts changed.
+
+  export --format json > $(date +%F).json
+  diff 2026-10-12.json 2026-10-19.json --format markdown`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch diffParams.format {
+		case "table", "json", "markdown":
+		default:
+			return fmt.Errorf("unknown format: %s (valid: table, json, markdown)", diffParams.format)
+		}
+		rd := redactor()
+		if err := rd.Validate(); err != nil {
+			return err
+		}
+		if err := requireItems(); err != nil {
+			return err
+		}
+		prev, err := readReportFile(args[0])
+		if err != nil {
+			return err
+		}
+		next, err := readReportFile(args[1])
+		if err != nil {
+			return err
+		}
+		prev.Items, next.Items = rd.Redact(prev.Items), rd.Redact(next.Items)
+
+		d := contrib.DiffReports(prev, next)
+		if diffParams.format == "json" {
+			e := json.NewEncoder(os.Stdout)
+			e.SetIndent("", "  ")
+			return e.Encode(d)
+		}
+		r, err := newTableRenderer()
+		if err != nil {
+			return err
+		}
+		return r.RenderDiff(os.Stdout, d, diffParams.format == "markdown")
+	},
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffParams.format, "format", "table", "output format: table, json, markdown")
+	addRedactFlags(diffCmd)
+	addStyleFlags(diffCmd)
+	diffCmd.Flags().UintVar(&params.width, "width", 0, "max output width")
+	rootCmd.AddCommand(diffCmd)
+}
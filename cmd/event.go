@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+)
+
+var eventParams struct {
+	rules string
+}
+
+var eventCmd = &cobra.Command{
+	Use:   "event",
+	Short: "check which pull requests count for an event such as Hacktoberfest",
+	Long: `Check which pull requests of the account count for an event such as
+Hacktoberfest, and the progress toward the event's target.
+
+The rules file looks like this:
+
+  name: Hacktoberfest 2026
+  start: 2026-10-01
+  end: 2026-10-31
+  target: 4
+  topics: [hacktoberfest]                  # or an accepted label
+  accepted_labels: [hacktoberfest-accepted]
+  states: [merged, approved]               # or an accepted label
+  invalid_labels: [spam, invalid]`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if eventParams.rules == "" {
+			return errors.New("rules file is not specified")
+		}
+		if params.account == "" {
+			return errors.New("account name is not specified")
+		}
+		rd := redactor()
+		if err := rd.Validate(); err != nil {
+			return err
+		}
+		if err := requireItems(); err != nil {
+			return err
+		}
+		rules, err := contrib.LoadEventRules(eventParams.rules)
+		if err != nil {
+			return err
+		}
+		if err := setToken(); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		client, err := contrib.NewClient(ctx, params.token, params.host)
+		if err != nil {
+			return err
+		}
+		prs, info, err := client.FetchEvent(ctx, params.account, rules)
+		if err != nil {
+			return err
+		}
+
+		r, err := newTableRenderer()
+		if err != nil {
+			return err
+		}
+		// the labels and topics are needed to evaluate the rules
+		result := rules.Evaluate(prs, info)
+		items := make([]contrib.Contribution, len(result.PRs))
+		for i, pr := range result.PRs {
+			items[i] = pr.Contribution
+		}
+		for i, c := range rd.Redact(items) {
+			result.PRs[i].Contribution = c
+		}
+		return r.RenderEvent(os.Stdout, result)
+	},
+}
+
+func init() {
+	eventCmd.Flags().StringVar(&eventParams.rules, "rules", "", "YAML file with the rules of the event")
+	eventCmd.Flags().StringVar(&params.token, "token", "", "github token (prefer GITHUB_TOKEN or --token-stdin)")
+	eventCmd.Flags().BoolVar(&params.tokenStdin, "token-stdin", false, "read github token from stdin")
+	eventCmd.Flags().StringVar(&params.account, "account", "", "your github account name")
+	addRedactFlags(eventCmd)
+	addStyleFlags(eventCmd)
+	eventCmd.Flags().UintVar(&params.width, "width", 0, "max output width")
+	rootCmd.AddCommand(eventCmd)
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+)
+
+var exportParams struct {
+	format     string
+	sign       bool
+	signingKey string
+	signature  string
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "write contributions as JSON, CSV or OpenMetrics to stdout",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contributions, err := retrieveData()
+		if err != nil {
+			return err
+		}
+		if exportParams.format != "openmetrics" {
+			if err := requireItems(); err != nil {
+				return err
+			}
+		}
+		if exportParams.sign && exportParams.format != "json" {
+			return errors.New("--sign needs --format json")
+		}
+		report, err := newReport(contributions)
+		if err != nil {
+			return err
+		}
+		switch exportParams.format {
+		case "json":
+			if exportParams.sign {
+				return writeSignedReport(report)
+			}
+			return report.WriteJSON(os.Stdout)
+		case "jsonl":
+			return report.WriteJSONL(os.Stdout)
+		case "csv":
+			return contrib.RenderCSV(os.Stdout, contributions)
+		case "openmetrics":
+			return contrib.WriteOpenMetrics(os.Stdout, report)
+		default:
+			return fmt.Errorf("unknown export format: %s (valid: json, jsonl, csv, openmetrics)", exportParams.format)
+		}
+	},
+}
+
+// newReport returns a report of the contributions. The account, the sources,
+// the fetch time and the queries of a report read with --from or --cached
+// are kept, so that a signature vouches for the fetch they describe. Its tool
+// version is kept too unless the items were transformed again, which is
+// recorded after its transformations.
+func newReport(contributions []contrib.Contribution) (contrib.Report, error) {
+	r := contrib.NewReport(params.account, sourceNames(), version, contributions)
+	r.Transformations = transformations()
+	if loadedReport != nil {
+		if len(r.Transformations) == 0 {
+			r.ToolVersion = loadedReport.ToolVersion
+		}
+		r.Account = loadedReport.Account
+		r.Sources = loadedReport.Sources
+		r.FetchedAt = loadedReport.FetchedAt
+		r.Queries = loadedReport.Queries
+		r.Transformations = append(append([]string(nil), loadedReport.Transformations...), r.Transformations...)
+		return r, nil
+	}
+	sources, err := parseSources(params.sources)
+	if err != nil {
+		return r, err
+	}
+	if _, ok := sources["github"]; ok && params.account != "" {
+		r.Queries = contrib.GitHubFetcher{Account: params.account, Reviews: params.reviews}.Queries()
+	}
+	return r, nil
+}
+
+// transformations returns the options which filter, redact or assign
+// foundations to the items, as given on the command line. The catalog is
+// named by its file name only, as its path may be private.
+func transformations() []string {
+	var t []string
+	if len(params.labels) > 0 {
+		t = append(t, "--label "+strings.Join(params.labels, ","))
+	}
+	if len(params.excludeLabels) > 0 {
+		t = append(t, "--exclude-label "+strings.Join(params.excludeLabels, ","))
+	}
+	if params.catalog != "" {
+		t = append(t, "--catalog "+filepath.Base(params.catalog))
+	}
+	if params.minStars > 0 {
+		t = append(t, "--min-stars "+strconv.Itoa(params.minStars))
+	}
+	if len(params.topics) > 0 {
+		t = append(t, "--topic "+strings.Join(params.topics, ","))
+	}
+	if params.excludeArchived {
+		t = append(t, "--exclude-archived")
+	}
+	if params.redact != "" {
+		t = append(t, "--redact "+params.redact)
+	}
+	return t
+}
+
+// writeSignedReport writes the report with its summaries to stdout and an
+// armored detached signature of it to --signature.
+func writeSignedReport(r contrib.Report) error {
+	if exportParams.signingKey == "" || exportParams.signature == "" {
+		return errors.New("--sign needs --signing-key and --signature")
+	}
+	signer, err := readSigningKey(exportParams.signingKey)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	if err := r.WithSummaries().WriteJSON(&b); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(exportParams.signature, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if err := contrib.SignDetached(f, b.Bytes(), signer); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(b.Bytes())
+	return err
+}
+
+func readReportFile(path string) (contrib.Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return contrib.Report{}, err
+	}
+	defer f.Close()
+
+	r, err := contrib.ReadReport(f)
+	if err != nil {
+		return r, fmt.Errorf("failed to read report %s: %w", path, err)
+	}
+	return r, nil
+}
+
+func writeReportFile(path string, r contrib.Report) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if err := r.WriteJSON(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func init() {
+	addSourceFlags(exportCmd)
+	addCachedFlags(exportCmd)
+	addRedactFlags(exportCmd)
+	exportCmd.Flags().StringVar(&exportParams.format, "format", "json", "export format: json, jsonl, csv, openmetrics")
+	exportCmd.Flags().BoolVar(&exportParams.sign, "sign", false, "sign the JSON report with an OpenPGP key, see verify")
+	exportCmd.Flags().StringVar(&exportParams.signingKey, "signing-key", "", "OpenPGP private key file to sign with")
+	exportCmd.Flags().StringVar(&exportParams.signature, "signature", "", "file to write the armored detached signature to")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+)
+
+func TestExportFromTransformations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := writeTestReport(t, dir, testContributions())
+	src, err := readReportFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                string
+		args                []string
+		wantToolVersion     string
+		wantTransformations []string
+		wantItems           int
+	}{
+		{name: "unchanged", wantToolVersion: "test", wantItems: 3},
+		{name: "redacted", args: []string{"--redact", "titles"}, wantToolVersion: version, wantTransformations: []string{"--redact titles"}, wantItems: 3},
+		{name: "filtered", args: []string{"--exclude-label", "docs", "--redact", "titles"}, wantToolVersion: version, wantTransformations: []string{"--exclude-label docs", "--redact titles"}, wantItems: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, _, err := executeCommand(t, append([]string{"export", "--format", "json", "--from", path}, tt.args...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var r contrib.Report
+			if err := json.Unmarshal([]byte(out), &r); err != nil {
+				t.Fatal(err)
+			}
+			if r.ToolVersion != tt.wantToolVersion {
+				t.Errorf("ToolVersion = %q, want %q", r.ToolVersion, tt.wantToolVersion)
+			}
+			if !reflect.DeepEqual(r.Transformations, tt.wantTransformations) {
+				t.Errorf("Transformations = %q, want %q", r.Transformations, tt.wantTransformations)
+			}
+			// the fetch they describe is kept
+			if !r.FetchedAt.Equal(src.FetchedAt) || r.Account != src.Account {
+				t.Errorf("FetchedAt, Account = %s, %q, want %s, %q", r.FetchedAt, r.Account, src.FetchedAt, src.Account)
+			}
+			if len(r.Items) != tt.wantItems {
+				t.Errorf("got %d items, want %d", len(r.Items), tt.wantItems)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+)
+
+var fetchCmd = &cobra.Command{
+	Use:   "fetch",
+	Short: "fetch contributions and store them in the cache",
+	Long: `Fetch contributions from all configured sources and store them in the
+cache. list, summary and export read the cache when given --cached.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contributions, err := fetchContributionData()
+		if err != nil {
+			return err
+		}
+		if err := enrichRepos(contributions, false); err != nil {
+			return err
+		}
+		path, err := cachePath()
+		if err != nil {
+			return err
+		}
+		report, err := newReport(contributions)
+		if err != nil {
+			return err
+		}
+		if err := writeReportFile(path, report); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "stored %d items in %s\n", len(contributions), path)
+		return nil
+	},
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "inspect or clear the cache written by fetch",
+}
+
+var cacheInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "show the cached report of the account",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := cachePath()
+		if err != nil {
+			return err
+		}
+		r, err := readReportFile(path)
+		if os.IsNotExist(err) {
+			return fmt.Errorf("nothing cached for %s, run fetch first", cacheKey())
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("path:       %s\n", path)
+		fmt.Printf("account:    %s\n", r.Account)
+		fmt.Printf("sources:    %s\n", strings.Join(r.Sources, ", "))
+		fmt.Printf("fetched at: %s (%s ago)\n", r.FetchedAt.Format(time.RFC3339), time.Since(r.FetchedAt).Round(time.Second))
+		fmt.Printf("items:      %d\n", len(r.Items))
+		return nil
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "remove all cached reports",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := cacheDir()
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "removed %s\n", dir)
+		return nil
+	},
+}
+
+// retrieveData returns the contributions from the report given with --from
+// or from the cache if --cached is given, otherwise it fetches them from the
+// configured sources. The contributions are classified, assigned to the
+// foundations of --catalog, scored, filtered by --label, --exclude-label and
+// the repo filters and redacted by --redact.
+func retrieveData() ([]contrib.Contribution, error) {
+	contributions, err := retrieveUnredactedData()
+	if err != nil {
+		return nil, err
+	}
+	r := redactor()
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r.Redact(contributions), nil
+}
+
+// retrieveUnredactedData is retrieveData without the redaction, for output
+// which has no items but matches repo names.
+func retrieveUnredactedData() ([]contrib.Contribution, error) {
+	contributions, err := readContributionData()
+	if err != nil {
+		return nil, err
+	}
+	classifier().Classify(contributions)
+	contributions = contrib.FilterLabels(contributions, params.labels, params.excludeLabels)
+	catalog, err := loadCatalog()
+	if err != nil {
+		return nil, err
+	}
+	if err := enrichRepos(contributions, catalog.HasTopics()); err != nil {
+		return nil, err
+	}
+	catalog.Assign(contributions)
+	scoreWeights().Assign(contributions)
+	return repoFilter().Filter(contributions), nil
+}
+
+// loadedReport is the report read by readContributionData with --from or
+// --cached, whose fetch time and queries are kept in exported reports.
+var loadedReport *contrib.Report
+
+func readContributionData() ([]contrib.Contribution, error) {
+	if params.from != "" {
+		if params.cached {
+			return nil, errors.New("--cached and --from cannot be used together")
+		}
+		r, err := readReportFile(params.from)
+		if err != nil {
+			return nil, err
+		}
+		loadedReport = &r
+		return r.Items, nil
+	}
+	if !params.cached {
+		return fetchContributionData()
+	}
+
+	path, err := cachePath()
+	if err != nil {
+		return nil, err
+	}
+	r, err := readReportFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("nothing cached for %s, run fetch first", cacheKey())
+	}
+	if err != nil {
+		return nil, err
+	}
+	loadedReport = &r
+	return r.Items, nil
+}
+
+// fetchContributionData reads contributions from every configured source.
+func fetchContributionData() ([]contrib.Contribution, error) {
+	sources, err := configuredSources()
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 && params.ledger == "" {
+		return nil, errors.New("no source is specified")
+	}
+
+	ctx := context.Background()
+	var fetchers []contrib.Fetcher
+	if _, ok := sources["github"]; ok {
+		if params.account == "" {
+			return nil, errors.New("account name is not specified")
+		}
+		err := setToken()
+		if err != nil {
+			return nil, err
+		}
+		client, err := contrib.NewClient(ctx, params.token, params.host)
+		if err != nil {
+			return nil, err
+		}
+		fetchers = append(fetchers, contrib.GitHubFetcher{
+			Client:        client,
+			Account:       params.account,
+			Reviews:       params.reviews,
+			ResponseTimes: params.responseTimes,
+			Sizes:         params.sizes,
+		})
+	}
+	if _, ok := sources["local"]; ok {
+		f := contrib.LocalFetcher{
+			Dirs:   params.localDirs,
+			Emails: params.authorEmails,
+			Names:  params.authorNames,
+		}
+		if params.warn {
+			f.Warnings = os.Stderr
+		}
+		fetchers = append(fetchers, f)
+	}
+	if params.ledger != "" {
+		fetchers = append(fetchers, contrib.LedgerFetcher{Path: params.ledger})
+	}
+
+	return contrib.FetchAll(ctx, fetchers...)
+}
+
+func repoFilter() contrib.RepoFilter {
+	return contrib.RepoFilter{MinStars: params.minStars, Topics: params.topics, ExcludeArchived: params.excludeArchived}
+}
+
+// repoInfoMaxAge is how long looked up repo metadata is reused.
+const repoInfoMaxAge = 24 * time.Hour
+
+// loadCatalog reads --catalog, an empty catalog matches nothing.
+func loadCatalog() (contrib.Catalog, error) {
+	if params.catalog == "" {
+		return contrib.Catalog{}, nil
+	}
+	return contrib.LoadCatalog(params.catalog)
+}
+
+// enrichRepos attaches the metadata of their repos to the contributions if
+// needed, or --repo-info or a repo filter is given. Reports which already
+// have it, like ones exported with --repo-info, are used as they are.
+func enrichRepos(contributions []contrib.Contribution, needed bool) error {
+	if !needed && !params.repoInfo && repoFilter().Empty() && !groupsByRepoInfo() {
+		return nil
+	}
+	var missing []contrib.Contribution
+	for _, c := range contributions {
+		if c.RepoInfo == nil && c.Type != contrib.Ledger {
+			missing = append(missing, c)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	path, err := repoInfoPath()
+	if err != nil {
+		return err
+	}
+	infos, err := contrib.LoadRepoInfos(path)
+	if err != nil {
+		return err
+	}
+	if stale := infos.Stale(missing, repoInfoMaxAge); len(stale) > 0 {
+		// --from renders without calling the API
+		if params.from != "" {
+			return fmt.Errorf("%s has no repo info for %d repos, export it with --repo-info", params.from, len(stale))
+		}
+		if err := setToken(); err != nil {
+			return err
+		}
+		ctx := context.Background()
+		client, err := contrib.NewClient(ctx, params.token, params.host)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "looking up %d repos\n", len(stale))
+		err = client.LookupRepos(ctx, stale, infos)
+		// the repos looked up before an error are kept for the next run
+		if err := infos.Save(path); err != nil {
+			return err
+		}
+		if err != nil {
+			return err
+		}
+	}
+	contrib.Enrich(contributions, infos)
+	return nil
+}
+
+func repoInfoPath() (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, params.host, "_repos.json"), nil
+}
+
+// sourceNames returns the names of the configured sources for reports.
+func sourceNames() []string {
+	sources, _ := configuredSources()
+	var names []string
+	for s := range sources {
+		names = append(names, s)
+	}
+	sort.Strings(names)
+	if params.ledger != "" {
+		names = append(names, "ledger")
+	}
+	return names
+}
+
+// configuredSources returns the sources of --source. The github source is
+// left out without --account if --ledger is given, so that a ledger alone
+// can be read with the default --source.
+func configuredSources() (map[string]struct{}, error) {
+	sources, err := parseSources(params.sources)
+	if err != nil {
+		return nil, err
+	}
+	if params.account == "" && params.ledger != "" {
+		delete(sources, "github")
+	}
+	return sources, nil
+}
+
+// parseSources parses the supplied source flag into a set of data sources.
+func parseSources(src string) (map[string]struct{}, error) {
+	sources := make(map[string]struct{})
+	for _, v := range strings.Split(src, ",") {
+		v = strings.TrimSpace(v)
+		if len(v) == 0 {
+			continue
+		}
+		switch v {
+		case "github", "local":
+			sources[v] = struct{}{}
+		default:
+			return nil, fmt.Errorf("unknown source: %s (valid: github, local)", v)
+		}
+	}
+	return sources, nil
+}
+
+func cacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oss-contribution-checker"), nil
+}
+
+// cacheKey names the cached report. Reports without a github account are
+// shared by all local and ledger only runs.
+func cacheKey() string {
+	if params.account == "" {
+		return "_local"
+	}
+	return params.account
+}
+
+func cachePath() (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, params.host, cacheKey()+".json"), nil
+}
+
+func init() {
+	addSourceFlags(fetchCmd)
+	rootCmd.AddCommand(fetchCmd)
+
+	cacheInfoCmd.Flags().StringVar(&params.account, "account", "", "your github account name")
+	cacheCmd.AddCommand(cacheInfoCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfiguredSources(t *testing.T) {
+	saved := params
+	defer func() { params = saved }()
+
+	tests := []struct {
+		name    string
+		sources string
+		account string
+		ledger  string
+		want    []string
+	}{
+		{name: "github", sources: "github", account: "alice", want: []string{"github"}},
+		{name: "ledger only", sources: "github", ledger: "ledger.yaml", want: nil},
+		{name: "ledger and local", sources: "github,local", ledger: "ledger.yaml", want: []string{"local"}},
+		{name: "account and ledger", sources: "github", account: "alice", ledger: "ledger.yaml", want: []string{"github"}},
+		{name: "no ledger", sources: "github", want: []string{"github"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params.sources, params.account, params.ledger = tt.sources, tt.account, tt.ledger
+			sources, err := configuredSources()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, s := range []string{"github", "local"} {
+				if _, ok := sources[s]; ok {
+					got = append(got, s)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("configuredSources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+)
+
+var followupParams struct {
+	format string
+}
+
+var followupCmd = &cobra.Command{
+	Use:   "followup",
+	Short: "list your open pull requests which need attention",
+	Long: `List the open pull requests of the account, the least recently updated
+first, with the last commenter, the combined CI state of the commit statuses
+and check runs, the review decision and the mergeable state.
+
+Each PR is flagged with why it is stuck, in this order:
+  draft              the PR is a draft, not ready for review
+  has conflicts      the branch conflicts with the base branch
+  waiting on CI      CI is failing or still running
+  waiting on author  changes were requested or someone else commented last
+  waiting on review  everything else
+
+While GitHub still computes the mergeable state, both the mergeable state
+and the reason are "pending", as it may hide conflicts.
+
+It takes a few API calls per PR.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if params.account == "" {
+			return errors.New("account name is not specified")
+		}
+		if followupParams.format != "table" && followupParams.format != "json" {
+			return fmt.Errorf("unknown format: %s (valid: table, json)", followupParams.format)
+		}
+		rd := redactor()
+		if err := rd.Validate(); err != nil {
+			return err
+		}
+		if err := requireItems(); err != nil {
+			return err
+		}
+		if err := setToken(); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		client, err := contrib.NewClient(ctx, params.token, params.host)
+		if err != nil {
+			return err
+		}
+		followUps, err := client.FetchFollowUps(ctx, params.account)
+		if err != nil {
+			return err
+		}
+		followUps = rd.RedactFollowUps(followUps)
+
+		if followupParams.format == "json" {
+			e := json.NewEncoder(os.Stdout)
+			e.SetIndent("", "  ")
+			return e.Encode(followUps)
+		}
+		r, err := newTableRenderer()
+		if err != nil {
+			return err
+		}
+		return r.RenderFollowUps(os.Stdout, followUps, time.Now())
+	},
+}
+
+func init() {
+	followupCmd.Flags().StringVar(&params.token, "token", "", "github token (prefer GITHUB_TOKEN or --token-stdin)")
+	followupCmd.Flags().BoolVar(&params.tokenStdin, "token-stdin", false, "read github token from stdin")
+	followupCmd.Flags().StringVar(&params.account, "account", "", "your github account name")
+	followupCmd.Flags().StringVar(&followupParams.format, "format", "table", "output format: table, json")
+	addRedactFlags(followupCmd)
+	addStyleFlags(followupCmd)
+	followupCmd.Flags().UintVar(&params.width, "width", 0, "max output width")
+	rootCmd.AddCommand(followupCmd)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+)
+
+var goalsParams struct {
+	path string
+}
+
+var goalsCmd = &cobra.Command{
+	Use:   "goals",
+	Short: "show the progress toward your contribution goals",
+	Long: `Show the progress toward the goals of a goals file in the current month,
+quarter or year, and whether each goal is reached at the current pace.
+Contributions count in the period they reached the state of the goal in,
+e.g. merged PRs when they were merged and reviews when you first reviewed.
+
+  goals:
+    - name: merged upstream PRs
+      type: pr              # issue, pr, review, commit, ledger
+      state: merged         # open, closed, merged (optional)
+      target: 5
+      period: quarter       # month, quarter, year
+      repos: [kubernetes/*, prometheus/*]   # optional
+      exclude_repos: [your-org/*]           # optional
+      foundations: [CNCF]   # optional, requires --catalog
+    - name: reviews
+      type: review
+      target: 10
+      period: quarter
+
+Exits with 2 if a goal is behind schedule.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if goalsParams.path == "" {
+			return errors.New("goals file is not specified")
+		}
+		goals, err := contrib.LoadGoals(goalsParams.path)
+		if err != nil {
+			return err
+		}
+		if goals.HasFoundations() && params.catalog == "" {
+			return errors.New("goals with foundations require --catalog")
+		}
+		if goals.HasType(contrib.Review) {
+			params.reviews = true
+		}
+
+		if err := redactor().Validate(); err != nil {
+			return err
+		}
+		// goals show no items, and redacted repo names would not match the
+		// repo patterns of the goals
+		contributions, err := retrieveUnredactedData()
+		if err != nil {
+			return err
+		}
+		progress := goals.Progress(contributions, time.Now())
+
+		r, err := newTableRenderer()
+		if err != nil {
+			return err
+		}
+		if err := r.RenderGoals(os.Stdout, progress); err != nil {
+			return err
+		}
+		for _, p := range progress {
+			if p.Behind() {
+				return exitWith(cmd, 2)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	addSourceFlags(goalsCmd)
+	addCachedFlags(goalsCmd)
+	addRedactFlags(goalsCmd)
+	goalsCmd.Flags().StringVar(&goalsParams.path, "goals", "", "YAML file with your goals")
+	addStyleFlags(goalsCmd)
+	goalsCmd.Flags().UintVar(&params.width, "width", 0, "max output width")
+	rootCmd.AddCommand(goalsCmd)
+}
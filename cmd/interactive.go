@@ -0,0 +1,533 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var interactiveCmd = &cobra.Command{
+	Use:   "interactive",
+	Short: "browse contributions interactively",
+	Long: `Browse contributions in a scrollable list.
+
+  up/down j/k pgup/pgdn g/G   move
+  tab                         switch between the list and the year and repo summaries
+  enter                       show the items of a summary row, open an item
+  /                           search titles, esc clears the search
+  t s y                       cycle the type, state and year filters
+  o                           open the item in the browser
+  esc backspace               go back from a summary row
+  q                           quit`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fd := int(os.Stdin.Fd())
+		if !terminal.IsTerminal(fd) || !terminal.IsTerminal(int(os.Stdout.Fd())) {
+			return errors.New("interactive needs a terminal")
+		}
+
+		if err := requireItems(); err != nil {
+			return err
+		}
+		contributions, err := retrieveData()
+		if err != nil {
+			return err
+		}
+		theme, err := contrib.LoadTheme(params.theme, termenv.EnvColorProfile())
+		if err != nil {
+			return err
+		}
+		style, err := contrib.ParseStyle(params.style)
+		if err != nil {
+			return err
+		}
+
+		b := newBrowser(contributions, theme, style.Box.MiddleVertical, style.Box.MiddleHorizontal, params.style == "ascii")
+		return b.run(fd)
+	},
+}
+
+// drill is the summary row the list is narrowed to.
+type drill struct {
+	group, key string
+	cursor     int
+}
+
+// browser is the state of the interactive mode.
+type browser struct {
+	items []contrib.Contribution
+	theme contrib.Theme
+
+	vertical, horizontal, snip string
+
+	view      string // "list", "year" or "repo"
+	drill     *drill
+	query     string
+	searching bool
+	typ       string
+	state     string
+	year      string
+
+	cursor, offset int
+	width, height  int
+	message        string
+}
+
+var browserViews = []string{"list", "year", "repo"}
+
+func newBrowser(items []contrib.Contribution, theme contrib.Theme, vertical, horizontal string, ascii bool) *browser {
+	b := &browser{
+		items:      items,
+		theme:      theme,
+		vertical:   vertical,
+		horizontal: horizontal,
+		snip:       "…",
+		view:       "list",
+		width:      80,
+		height:     24,
+	}
+	if ascii {
+		b.snip = "~"
+	}
+	sort.SliceStable(b.items, func(i, j int) bool {
+		return b.items[i].CreatedAt.After(b.items[j].CreatedAt)
+	})
+	return b
+}
+
+// run draws the browser on the alternate screen until q is pressed.
+func (b *browser) run(fd int) error {
+	old, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer terminal.Restore(fd, old)
+
+	fmt.Print(termenv.CSI + termenv.AltScreenSeq + termenv.CSI + termenv.HideCursorSeq)
+	defer fmt.Print(termenv.CSI + termenv.ShowCursorSeq + termenv.CSI + termenv.ExitAltScreenSeq)
+
+	buf := make([]byte, 32)
+	for {
+		if w, h, err := terminal.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 && h > 0 {
+			b.width, b.height = w, h
+		}
+		var frame bytes.Buffer
+		b.render(&frame)
+		if _, err := os.Stdout.Write(frame.Bytes()); err != nil {
+			return err
+		}
+
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return err
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			if b.handleKey(key) {
+				return nil
+			}
+		}
+	}
+}
+
+// parseKeys splits what was read from a raw terminal at once, e.g. pasted
+// text, into keys.
+func parseKeys(p []byte) []string {
+	if len(p) > 0 && p[0] == 0x1b {
+		return []string{parseKey(p)}
+	}
+	var keys []string
+	for _, r := range string(p) {
+		keys = append(keys, parseKey([]byte(string(r))))
+	}
+	return keys
+}
+
+// parseKey names a key read from a raw terminal.
+func parseKey(p []byte) string {
+	switch string(p) {
+	case "\x1b[A", "\x1bOA":
+		return "up"
+	case "\x1b[B", "\x1bOB":
+		return "down"
+	case "\x1b[5~":
+		return "pgup"
+	case "\x1b[6~":
+		return "pgdn"
+	case "\x1b[H", "\x1b[1~", "\x1bOH":
+		return "home"
+	case "\x1b[F", "\x1b[4~", "\x1bOF":
+		return "end"
+	case "\x1b":
+		return "esc"
+	case "\r", "\n":
+		return "enter"
+	case "\t":
+		return "tab"
+	case "\x7f", "\b":
+		return "backspace"
+	case "\x03":
+		return "ctrl-c"
+	}
+	return string(p)
+}
+
+// handleKey updates the state and reports whether to quit.
+func (b *browser) handleKey(key string) bool {
+	b.message = ""
+	if key == "ctrl-c" {
+		return true
+	}
+
+	if b.searching {
+		switch key {
+		case "enter":
+			b.searching = false
+		case "esc":
+			b.searching = false
+			b.query = ""
+		case "backspace":
+			if r := []rune(b.query); len(r) > 0 {
+				b.query = string(r[:len(r)-1])
+			}
+		default:
+			if !strings.HasPrefix(key, "\x1b") && text.RuneCount(key) == 1 && key >= " " {
+				b.query += key
+			}
+		}
+		b.cursor = 0
+		return false
+	}
+
+	rows := b.rowCount()
+	page := b.bodyHeight()
+	switch key {
+	case "q":
+		return true
+	case "up", "k":
+		b.cursor--
+	case "down", "j":
+		b.cursor++
+	case "pgup":
+		b.cursor -= page
+	case "pgdn", " ":
+		b.cursor += page
+	case "home", "g":
+		b.cursor = 0
+	case "end", "G":
+		b.cursor = rows - 1
+	case "/":
+		b.searching = true
+	case "tab":
+		b.drill = nil
+		b.view = cycle(browserViews, b.view)
+		b.cursor = 0
+	case "t":
+		b.typ = cycle(b.values(func(c contrib.Contribution) string { return string(c.Type) }), b.typ)
+		b.cursor = 0
+	case "s":
+		b.state = cycle(b.values(contrib.Contribution.State), b.state)
+		b.cursor = 0
+	case "y":
+		b.year = cycle(b.values(contrib.Contribution.Year), b.year)
+		b.cursor = 0
+	case "enter":
+		if b.view != "list" {
+			b.drillDown()
+		} else {
+			b.open()
+		}
+	case "o":
+		b.open()
+	case "esc", "backspace":
+		switch {
+		case b.query != "":
+			b.query = ""
+			b.cursor = 0
+		case b.drill != nil:
+			b.view = b.drill.group
+			b.cursor = b.drill.cursor
+			b.drill = nil
+		}
+	}
+	return false
+}
+
+func (b *browser) drillDown() {
+	summaries := b.summaries()
+	if b.cursor < 0 || b.cursor >= len(summaries) {
+		return
+	}
+	b.drill = &drill{group: b.view, key: summaries[b.cursor].Key, cursor: b.cursor}
+	b.view = "list"
+	b.cursor = 0
+}
+
+// open opens the URL of the selected item in the browser.
+func (b *browser) open() {
+	if b.view != "list" {
+		return
+	}
+	items := b.filtered()
+	if b.cursor < 0 || b.cursor >= len(items) {
+		return
+	}
+	url := items[b.cursor].URL
+	if url == "" {
+		b.message = "the item has no URL"
+		return
+	}
+	if err := openURL(url); err != nil {
+		b.message = err.Error()
+		return
+	}
+	b.message = "opened " + url
+}
+
+func openURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// cycle returns the value after cur in values, wrapping around.
+func cycle(values []string, cur string) string {
+	for i, v := range values {
+		if v == cur {
+			if i+1 < len(values) {
+				return values[i+1]
+			}
+			return values[0]
+		}
+	}
+	return values[0]
+}
+
+// values returns "" followed by the distinct sorted values of f over all
+// items. The empty value means no filter.
+func (b *browser) values(f func(contrib.Contribution) string) []string {
+	seen := make(map[string]bool)
+	values := []string{""}
+	for _, c := range b.items {
+		v := f(c)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		values = append(values, v)
+	}
+	sort.Strings(values[1:])
+	return values
+}
+
+// filtered returns the items matching the filters, the search and the
+// drilled down summary row.
+func (b *browser) filtered() []contrib.Contribution {
+	query := strings.ToLower(b.query)
+	var items []contrib.Contribution
+	for _, c := range b.items {
+		if b.typ != "" && string(c.Type) != b.typ {
+			continue
+		}
+		if b.state != "" && c.State() != b.state {
+			continue
+		}
+		if b.year != "" && c.Year() != b.year {
+			continue
+		}
+		if b.drill != nil && contrib.Groups[b.drill.group](c) != b.drill.key {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(c.Title), query) {
+			continue
+		}
+		items = append(items, c)
+	}
+	return items
+}
+
+func (b *browser) summaries() []contrib.Summary {
+	s, _ := contrib.SummarizeBy(b.filtered(), b.view)
+	return s
+}
+
+func (b *browser) rowCount() int {
+	if b.view == "list" {
+		return len(b.filtered())
+	}
+	return len(b.summaries())
+}
+
+// bodyHeight is the number of rows below the header and above the status
+// line.
+func (b *browser) bodyHeight() int {
+	if h := b.height - 3; h > 0 {
+		return h
+	}
+	return 1
+}
+
+// render writes a full frame.
+func (b *browser) render(w io.Writer) {
+	var header string
+	var rows [][]string
+	var colors []termenv.Color
+	var widths []int
+	if b.view == "list" {
+		widths = b.columnWidths(4, 6, 6, -30, -70)
+		header = b.line(widths, []string{"year", "type", "state", "repo", "title"})
+		colors = []termenv.Color{b.theme.Gray, b.theme.Magenta, nil, b.theme.Blue, nil}
+		for _, c := range b.filtered() {
+			rows = append(rows, []string{c.Year(), string(c.Type), c.State(), c.Repo, c.Title})
+		}
+	} else {
+		widths = b.columnWidths(-100, 7, 7, 7, 7, 7, 7)
+		header = b.line(widths, []string{b.view, "issues", "PRs", "commits", "others", "issue%", "PR%"})
+		colors = []termenv.Color{b.theme.Blue, nil, nil, nil, nil, b.theme.Green, b.theme.Green}
+		for _, s := range b.summaries() {
+			rows = append(rows, []string{
+				s.Key, strconv.Itoa(s.Issues), strconv.Itoa(s.PRs), strconv.Itoa(s.Commits), strconv.Itoa(s.Others),
+				fmt.Sprintf("%.1f", s.IssuePercent*100), fmt.Sprintf("%.1f", s.PRPercent*100),
+			})
+		}
+	}
+
+	// keep the cursor in range and visible
+	if b.cursor >= len(rows) {
+		b.cursor = len(rows) - 1
+	}
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+	page := b.bodyHeight()
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	}
+	if b.cursor >= b.offset+page {
+		b.offset = b.cursor - page + 1
+	}
+
+	fmt.Fprintf(w, termenv.CSI+termenv.CursorPositionSeq, 1, 1)
+	writeLine(w, termenv.String(header).Bold().String())
+	writeLine(w, strings.Repeat(b.horizontal, b.width))
+	for i := b.offset; i < b.offset+page; i++ {
+		if i >= len(rows) {
+			writeLine(w, "")
+			continue
+		}
+		if i == b.cursor {
+			writeLine(w, termenv.String(b.line(widths, rows[i])).Reverse().String())
+			continue
+		}
+		colored := make([]string, len(rows[i]))
+		for j, v := range rows[i] {
+			colored[j] = b.cell(v, widths[j])
+			if colors[j] != nil {
+				colored[j] = termenv.String(colored[j]).Foreground(colors[j]).String()
+			}
+		}
+		writeLine(w, strings.Join(colored, " "+b.vertical+" "))
+	}
+	fmt.Fprint(w, text.Snip(b.status(len(rows)), b.width, b.snip))
+	fmt.Fprintf(w, termenv.CSI+termenv.EraseLineSeq, 0)
+}
+
+func writeLine(w io.Writer, s string) {
+	fmt.Fprintf(w, "%s"+termenv.CSI+termenv.EraseLineSeq+"\r\n", s, 0)
+}
+
+// columnWidths returns the widths of the columns. Positive values are fixed
+// widths, negative ones are percentages of the remaining width.
+func (b *browser) columnWidths(spec ...int) []int {
+	rest := b.width - (len(spec)-1)*(text.RuneCount(b.vertical)+2)
+	for _, s := range spec {
+		if s > 0 {
+			rest -= s
+		}
+	}
+	if rest < 0 {
+		rest = 0
+	}
+	widths := make([]int, len(spec))
+	for i, s := range spec {
+		if s > 0 {
+			widths[i] = s
+		} else {
+			widths[i] = rest * -s / 100
+		}
+	}
+	return widths
+}
+
+func (b *browser) line(widths []int, values []string) string {
+	cells := make([]string, len(values))
+	for i, v := range values {
+		cells[i] = b.cell(v, widths[i])
+	}
+	return strings.Join(cells, " "+b.vertical+" ")
+}
+
+func (b *browser) cell(v string, width int) string {
+	if text.RuneCount(v) > width {
+		v = text.Snip(v, width, b.snip)
+	}
+	return text.Pad(v, width, ' ')
+}
+
+func (b *browser) status(rows int) string {
+	if b.searching {
+		return "/" + b.query
+	}
+
+	view := b.view
+	if b.drill != nil {
+		view = b.drill.group + " " + b.drill.key
+	}
+	s := fmt.Sprintf("[%s] type:%s state:%s year:%s", view, all(b.typ), all(b.state), all(b.year))
+	if b.query != "" {
+		s += " search:" + b.query
+	}
+	s += fmt.Sprintf(" %s %d/%d", b.vertical, b.cursor+1, rows)
+	if b.message != "" {
+		s += " " + b.vertical + " " + b.message
+	}
+	return s
+}
+
+func all(filter string) string {
+	if filter == "" {
+		return "all"
+	}
+	return filter
+}
+
+func init() {
+	addSourceFlags(interactiveCmd)
+	addCachedFlags(interactiveCmd)
+	addRedactFlags(interactiveCmd)
+	addStyleFlags(interactiveCmd)
+	rootCmd.AddCommand(interactiveCmd)
+}
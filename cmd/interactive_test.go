@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{in: "\x1b[A", want: []string{"up"}},
+		{in: "\x1bOB", want: []string{"down"}},
+		{in: "\x1b[5~", want: []string{"pgup"}},
+		{in: "\x1b[4~", want: []string{"end"}},
+		{in: "\x1b", want: []string{"esc"}},
+		{in: "\r", want: []string{"enter"}},
+		{in: "\t", want: []string{"tab"}},
+		{in: "\x7f", want: []string{"backspace"}},
+		{in: "\x03", want: []string{"ctrl-c"}},
+		// pasted text is split into keys
+		{in: "ab\r", want: []string{"a", "b", "enter"}},
+		{in: "ü", want: []string{"ü"}},
+		// unknown escape sequences are kept whole
+		{in: "\x1b[Z", want: []string{"\x1b[Z"}},
+	}
+	for _, tt := range tests {
+		if got := parseKeys([]byte(tt.in)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseKeys(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCycle(t *testing.T) {
+	values := []string{"", "issue", "pr"}
+	tests := []struct {
+		cur, want string
+	}{
+		{cur: "", want: "issue"},
+		{cur: "issue", want: "pr"},
+		{cur: "pr", want: ""},
+		// a value which is gone starts over
+		{cur: "commit", want: ""},
+	}
+	for _, tt := range tests {
+		if got := cycle(values, tt.cur); got != tt.want {
+			t.Errorf("cycle(%q) = %q, want %q", tt.cur, got, tt.want)
+		}
+	}
+}
+
+// titles returns the titles of the items the browser lists.
+func titles(b *browser) []string {
+	var s []string
+	for _, c := range b.filtered() {
+		s = append(s, c.Title)
+	}
+	return s
+}
+
+func TestBrowserFilters(t *testing.T) {
+	b := newBrowser(testContributions(), contrib.Theme{}, "|", "-", true)
+	if got, want := titles(b), []string{"Fix the crash", "Add docs", "Crash on start"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("items = %q, want %q, the newest first", got, want)
+	}
+
+	tests := []struct {
+		keys []string
+		want []string
+	}{
+		{keys: []string{"t"}, want: []string{"Crash on start"}},
+		{keys: []string{"t", "t"}, want: []string{"Fix the crash", "Add docs"}},
+		// the filter wraps around to all types
+		{keys: []string{"t", "t", "t"}, want: []string{"Fix the crash", "Add docs", "Crash on start"}},
+		{keys: []string{"s"}, want: []string{"Fix the crash"}},
+		{keys: []string{"s", "s"}, want: []string{"Add docs", "Crash on start"}},
+		{keys: []string{"y"}, want: []string{"Crash on start"}},
+		{keys: []string{"y", "t", "t"}, want: nil},
+		{keys: []string{"/", "C", "r", "enter"}, want: []string{"Fix the crash", "Crash on start"}},
+		{keys: []string{"/", "d", "o", "x", "backspace", "enter"}, want: []string{"Add docs"}},
+		// esc clears the search
+		{keys: []string{"/", "d", "o", "enter", "esc"}, want: []string{"Fix the crash", "Add docs", "Crash on start"}},
+	}
+	for _, tt := range tests {
+		b := newBrowser(testContributions(), contrib.Theme{}, "|", "-", true)
+		for _, k := range tt.keys {
+			b.handleKey(k)
+		}
+		if got := titles(b); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("after %q: items = %q, want %q", tt.keys, got, tt.want)
+		}
+	}
+}
+
+func TestBrowserDrillDown(t *testing.T) {
+	b := newBrowser(testContributions(), contrib.Theme{}, "|", "-", true)
+	b.handleKey("tab")
+	b.handleKey("tab")
+	if b.view != "repo" {
+		t.Fatalf("view = %s, want repo", b.view)
+	}
+	b.handleKey("down")
+	b.handleKey("enter")
+	if b.view != "list" || b.drill == nil || b.drill.key != "b/b" {
+		t.Fatalf("view = %s, drill = %+v, want the list of b/b", b.view, b.drill)
+	}
+	if got, want := titles(b), []string{"Add docs"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items of b/b = %q, want %q", got, want)
+	}
+
+	// back to the summary row drilled into
+	b.handleKey("backspace")
+	if b.view != "repo" || b.drill != nil || b.cursor != 1 {
+		t.Errorf("view = %s, drill = %+v, cursor = %d, want repo, nil, 1", b.view, b.drill, b.cursor)
+	}
+	if b.handleKey("q") != true {
+		t.Error("q does not quit")
+	}
+}
+
+func TestColumnWidths(t *testing.T) {
+	b := &browser{vertical: "|", width: 80}
+	// 3 separators of 3 runes and 10 fixed leave 61, split 30 and 70 percent
+	if got, want := b.columnWidths(4, 6, -30, -70), []int{4, 6, 18, 42}; !reflect.DeepEqual(got, want) {
+		t.Errorf("columnWidths() = %v, want %v", got, want)
+	}
+	b.width = 10
+	if got, want := b.columnWidths(4, 6, -30, -70), []int{4, 6, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("columnWidths() at width 10 = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var listParams struct {
+	explain bool
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list every issue, PR, commit and ledger entry",
+	Long: `List every issue, PR, commit and ledger entry. With --explain, show how the
+score of every item is multiplied from the weights of its type, state, star
+tier of the repo (needs --repo-info) and size (needs --sizes for PRs) instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contributions, err := retrieveData()
+		if err != nil {
+			return err
+		}
+		if !listParams.explain {
+			return showTable(contributions, "")
+		}
+		if err := requireItems(); err != nil {
+			return err
+		}
+		r, err := newTableRenderer()
+		if err != nil {
+			return err
+		}
+		return r.RenderScores(os.Stdout, contributions, scoreWeights())
+	},
+}
+
+func init() {
+	addSourceFlags(listCmd)
+	addRenderFlags(listCmd)
+	listCmd.Flags().BoolVar(&listParams.explain, "explain", false, "break the score of every item down by factor")
+	rootCmd.AddCommand(listCmd)
+}
@@ -1,209 +1,260 @@
 package cmd
 
 import (
-	"context"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
-	"strconv"
 	"strings"
-	"time"
 
-	"github.com/google/go-github/v32/github"
+	"github.com/binoue/oss-contribution-checker/contrib"
 	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
-	"golang.org/x/oauth2"
-	"gopkg.in/yaml.v2"
-)
-
-var (
-	term  = termenv.EnvColorProfile()
-	theme Theme
 )
 
 var params struct {
-	token   string
-	account string
-	summary bool
-	repo    bool
-	exclude string // TODO: implement this option
+	token      string
+	tokenStdin bool
+	secretKey  string
+	host       string
+	account    string
+	summary    bool
+	repo       bool
+
+	sources       string
+	labels        []string
+	excludeLabels []string
+	reviews       bool
+	responseTimes bool
+	sizes         bool
+	localDirs     []string
+	authorEmails  []string
+	authorNames   []string
+	ledger        string
+
+	repoInfo        bool
+	minStars        int
+	topics          []string
+	excludeArchived bool
+	catalog         string
+
+	config string
+	view   string
+	cached bool
+	from   string
+
+	redact        string
+	redactSalt    string
+	internalRepos []string
 
 	theme  string
 	style  string
 	output string
 	sort   string
 	width  uint
+	links  string
 	warn   bool
 	json   bool
 }
 
-type Token struct {
-	GithubToken string `yaml:"github_token"`
-}
-
 var rootCmd = &cobra.Command{
 	Use:   "oss-contribution-checker",
 	Short: "oss-contribution-checker",
 	Long:  `"oss-contribution-checker is a tool for showing your OSS contributions.`,
 
+	// Running without a subcommand is kept for the old flag combinations.
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if params.account == "" {
+		if params.repo && !params.summary {
+			return errors.New("--repo requires --summary")
+		}
+		if params.json && params.summary {
+			return errors.New("--json cannot be combined with --summary")
+		}
+		if params.account == "" && params.sources == "github" && params.ledger == "" && params.from == "" && !params.cached {
 			return errors.New("account name is not specified")
 		}
-		err := setToken()
+
+		contributions, err := retrieveData()
 		if err != nil {
 			return err
 		}
-		results, err := retrieveContributionData()
-		if err != nil {
-			return err
+		if params.json {
+			fmt.Fprintln(os.Stderr, `running without a subcommand is deprecated, use "export --format json"`)
+			if err := requireItems(); err != nil {
+				return err
+			}
+			report, err := newReport(contributions)
+			if err != nil {
+				return err
+			}
+			return report.WriteJSON(os.Stdout)
+		}
+		switch {
+		case params.repo:
+			fmt.Fprintln(os.Stderr, `running without a subcommand is deprecated, use "summary repo"`)
+			return showTable(contributions, "repo")
+		case params.summary:
+			fmt.Fprintln(os.Stderr, `running without a subcommand is deprecated, use "summary year"`)
+			return showTable(contributions, "year")
+		default:
+			fmt.Fprintln(os.Stderr, `running without a subcommand is deprecated, use "list"`)
+			return showTable(contributions, "")
 		}
-
-		return showTable(results)
 	},
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		var code exitCode
+		if errors.As(err, &code) {
+			os.Exit(int(code))
+		}
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
 
-func retrieveContributionData() ([]GithubIssue, error) {
-	ctx := context.Background()
-	token := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: params.token},
-	)
-	c := oauth2.NewClient(ctx, token)
-	gc := github.NewClient(c)
+// exitCode is returned by commands which have printed their result already
+// and only need to exit with the code.
+type exitCode int
 
-	query := "author:" + params.account
-	opts := github.SearchOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
+func (c exitCode) Error() string {
+	return fmt.Sprintf("exit status %d", int(c))
+}
 
-	// pagenation
-	var results []*github.IssuesSearchResult
-	for {
-		result, resp, err := gc.Search.Issues(ctx, query, &opts)
-		if err != nil {
-			return nil, err
-		}
-		results = append(results, result)
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
-		time.Sleep(time.Duration(1))
-	}
+// exitWith makes the command exit with code without printing an error.
+func exitWith(cmd *cobra.Command, code int) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return exitCode(code)
+}
 
-	var githubIssues []GithubIssue
-	for _, sr := range results {
-		for _, i := range sr.Issues {
-			year := strconv.Itoa((i.CreatedAt).Year())
-			s := strings.Split(*i.RepositoryURL, "/")
-			var closed bool
-			if i.ClosedAt != nil {
-				closed = true
-			}
-			// TODO: use exclude option
-			githubIssues = append(githubIssues, GithubIssue{
-				title:    *i.Title,
-				year:     year,
-				project:  strings.Join(s[len(s)-2:], "/"),
-				isPR:     i.IsPullRequest(),
-				isClosed: closed,
-			})
-		}
+func init() {
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return applyConfig(cmd)
 	}
 
-	return githubIssues, nil
+	rootCmd.PersistentFlags().StringVar(&params.config, "config", defaultConfigPath(), "config file with default flag values and views")
+	rootCmd.PersistentFlags().StringVar(&params.view, "view", "", "named view from the config file")
+	rootCmd.PersistentFlags().StringVar(&params.host, "host", "github.com", "github host name")
+	rootCmd.PersistentFlags().StringVar(&params.secretKey, "secret-key", "", "OpenPGP private key file to decrypt a token stored for a public key")
+
+	// deprecated flags of the subcommand-less invocation
+	addSourceFlags(rootCmd)
+	addRenderFlags(rootCmd)
+	rootCmd.Flags().BoolVar(&params.summary, "summary", false, "show summary")
+	rootCmd.Flags().BoolVar(&params.repo, "repo", false, "summary grouped by repo name")
+	rootCmd.Flags().BoolVar(&params.json, "json", false, "output all contributions in JSON format")
+	_ = rootCmd.Flags().MarkDeprecated("summary", `use "summary year" or "summary repo"`)
+	_ = rootCmd.Flags().MarkDeprecated("repo", `use "summary repo"`)
+	_ = rootCmd.Flags().MarkDeprecated("json", `use "export --format json"`)
 }
 
-func setToken() error {
-	if params.token != "" {
-		return nil
-	}
-	b, err := ioutil.ReadFile("token.txt")
-	if err == nil {
-		params.token = strings.TrimSuffix(string(b), "\n")
-		return nil
+// addSourceFlags adds the flags selecting where contributions are read from.
+func addSourceFlags(c *cobra.Command) {
+	c.Flags().StringVar(&params.token, "token", "", "github token (prefer GITHUB_TOKEN or --token-stdin)")
+	c.Flags().BoolVar(&params.tokenStdin, "token-stdin", false, "read github token from stdin")
+	c.Flags().StringVar(&params.account, "account", "", "your github account name")
+	c.Flags().StringVar(&params.sources, "source", "github", "data sources: github, local (comma separated)")
+	c.Flags().BoolVar(&params.reviews, "reviews", false, "also fetch the pull requests you reviewed and when, one API call each (github source)")
+	c.Flags().BoolVar(&params.responseTimes, "response-times", false, "also fetch the first maintainer response of every issue and PR, one or two API calls each (github source)")
+	c.Flags().BoolVar(&params.sizes, "sizes", false, "also fetch the lines changed by every PR for the score, one API call each (github source)")
+	c.Flags().StringSliceVar(&params.localDirs, "local-dir", nil, "directories to scan for git clones (local source)")
+	c.Flags().StringSliceVar(&params.authorEmails, "author-email", nil, "your commit author emails (local source)")
+	c.Flags().StringSliceVar(&params.authorNames, "author-name", nil, "your commit author names (local source)")
+	c.Flags().StringSliceVar(&params.labels, "label", nil, "only count issues and PRs with any of these labels")
+	c.Flags().StringSliceVar(&params.excludeLabels, "exclude-label", nil, "do not count issues and PRs with any of these labels")
+	c.Flags().StringVar(&params.ledger, "ledger", "", "YAML or JSON file listing contributions which are not on any forge")
+	c.Flags().BoolVar(&params.warn, "warnings", false, "output all warnings to STDERR")
+	c.Flags().BoolVar(&params.repoInfo, "repo-info", false, "look up stars, topics, license, archived, fork and last commit of every github repo, cached for a day")
+	c.Flags().IntVar(&params.minStars, "min-stars", 0, "only count repos with at least this many stars (implies --repo-info)")
+	c.Flags().StringSliceVar(&params.topics, "topic", nil, "only count repos with any of these topics (implies --repo-info)")
+	c.Flags().BoolVar(&params.excludeArchived, "exclude-archived", false, "do not count archived repos (implies --repo-info)")
+	c.Flags().StringVar(&params.catalog, "catalog", "", "YAML file mapping orgs, repos and topics to foundations")
+}
+
+// addCachedFlags adds the flags reading previously fetched contributions
+// instead of fetching them.
+func addCachedFlags(c *cobra.Command) {
+	c.Flags().BoolVar(&params.cached, "cached", false, "read contributions from the cache written by fetch")
+	c.Flags().StringVar(&params.from, "from", "", "read contributions from a JSON or JSONL report written by export instead of fetching them")
+}
+
+// addRedactFlags adds the flags redacting contributions before they are
+// rendered.
+func addRedactFlags(c *cobra.Command) {
+	c.Flags().StringVar(&params.redact, "redact", "", "redact for sharing publicly: "+strings.Join(contrib.RedactLevels, ", ")+" (each includes the ones before)")
+	c.Flags().StringVar(&params.redactSalt, "redact-salt", "", "salt hashed with private and internal repo names, keep it to get the same hashes")
+	c.Flags().StringSliceVar(&params.internalRepos, "internal-repo", nil, `repos hashed like private ones with --redact repos, e.g. "myorg/*"`)
+}
+
+// redactor returns the redactor configured by the redact flags.
+func redactor() contrib.Redactor {
+	return contrib.Redactor{Level: params.redact, Salt: params.redactSalt, Internal: params.internalRepos}
+}
+
+// requireItems fails if single items must not be shown.
+func requireItems() error {
+	if redactor().AggregateOnly() {
+		return fmt.Errorf("--redact %s shows only aggregates such as summaries", contrib.RedactAggregate)
 	}
-	home, err := os.UserHomeDir()
+	return nil
+}
+
+// addRenderFlags adds the flags controlling the table output.
+func addRenderFlags(c *cobra.Command) {
+	addCachedFlags(c)
+	addRedactFlags(c)
+
+	addStyleFlags(c)
+	c.Flags().UintVar(&params.width, "width", 0, "max output width")
+	c.Flags().StringVar(&params.output, "output", "", "output fields: "+strings.Join(contrib.ColumnIDs(), ", "))
+	c.Flags().StringVar(&params.links, "hyperlinks", "auto", "make titles terminal hyperlinks: auto, always, never")
+	c.Flags().StringVar(&params.sort, "sort", "", "sort output by: "+strings.Join(contrib.ColumnIDs(), ", ")+", descending with a leading \"-\" (default: the first default column)")
+}
+
+// showTable renders the contributions, or their summaries if group is not
+// empty.
+func showTable(contributions []contrib.Contribution, group string) error {
+	r, err := newTableRenderer()
 	if err != nil {
 		return err
 	}
-	b, err = ioutil.ReadFile(home + "/.git-neco.yml")
-	if err != nil {
-		return err
+	if group == "" {
+		if err := requireItems(); err != nil {
+			return err
+		}
+		return r.RenderList(os.Stdout, contributions)
 	}
-	var t Token
-	err = yaml.Unmarshal(b, &t)
+	summaries, err := contrib.SummarizeBy(contributions, group)
 	if err != nil {
 		return err
 	}
-	params.token = strings.TrimSuffix(string(t.GithubToken), "\n")
-	if params.token == "" {
-		return errors.New("failed to get token")
-	}
-	return nil
+	return r.RenderSummary(os.Stdout, group, summaries)
 }
 
-func init() {
-	rootCmd.Flags().BoolVar(&params.summary, "summary", false, "show summary")
-	rootCmd.Flags().StringVar(&params.token, "token", "", "github token")
-	rootCmd.Flags().StringVar(&params.account, "account", "", "your github account name")
-	rootCmd.Flags().BoolVar(&params.repo, "repo", false, "summary grouped by repo name")
-
+// addStyleFlags adds the flags controlling the look of tables.
+func addStyleFlags(c *cobra.Command) {
 	// Took from duf
-	rootCmd.Flags().StringVar(&params.theme, "theme", defaultThemeName(), "color themes: dark, light")
-	rootCmd.Flags().StringVar(&params.style, "style", defaultStyleName(), "style: unicode, ascii")
-	rootCmd.Flags().StringVar(&params.output, "output", "", "output fields: "+strings.Join(columnIDs(), ", "))
-	rootCmd.Flags().StringVar(&params.sort, "sort", "mountpoint", "sort output by: "+strings.Join(columnIDs(), ", "))
-	rootCmd.Flags().UintVar(&params.width, "width", 0, "max output width")
-	rootCmd.Flags().BoolVar(&params.warn, "warnings", false, "output all warnings to STDERR")
-	rootCmd.Flags().BoolVar(&params.json, "json", false, "output all devices in JSON format")
-}
-
-func showTable(githubIssues []GithubIssue) error {
-	var err error
-	theme, err = loadTheme(params.theme)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		return err
-	}
+	c.Flags().StringVar(&params.theme, "theme", contrib.DefaultThemeName(), "color themes: dark, light")
+	c.Flags().StringVar(&params.style, "style", defaultStyleName(), "style: unicode, ascii")
+}
 
-	style, err := parseStyle(params.style)
+// newTableRenderer returns a renderer configured by the render flags.
+func newTableRenderer() (contrib.TableRenderer, error) {
+	theme, err := contrib.LoadTheme(params.theme, termenv.EnvColorProfile())
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		return err
+		return contrib.TableRenderer{}, err
 	}
 
-	columns, err := parseColumns(params.output)
+	style, err := contrib.ParseStyle(params.style)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		return err
-	}
-
-	if len(columns) == 0 {
-		if params.summary {
-			if params.repo {
-				columns = []int{3, 5, 6, 7, 8}
-			} else {
-				columns = []int{1, 5, 6, 7, 8}
-			}
-		} else {
-			columns = []int{1, 2, 3, 4}
-		}
+		return contrib.TableRenderer{}, err
 	}
 
-	sortCol, err := stringToSortIndex(params.sort)
+	columns, err := contrib.ParseColumns(params.output)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		return err
+		return contrib.TableRenderer{}, err
 	}
 
 	// detect terminal width
@@ -211,16 +262,44 @@ func showTable(githubIssues []GithubIssue) error {
 	if isTerminal && params.width == 0 {
 		w, _, err := terminal.GetSize(int(os.Stdout.Fd()))
 		if err != nil {
-			return err
+			return contrib.TableRenderer{}, err
 		}
 		params.width = uint(w)
 	}
-	if params.width == 0 {
-		params.width = 80
+
+	// commands without --hyperlinks leave it empty
+	var hyperlinks bool
+	if params.links != "" {
+		hyperlinks, err = useHyperlinks(params.links, isTerminal)
+		if err != nil {
+			return contrib.TableRenderer{}, err
+		}
 	}
 
-	customRenderTables(githubIssues, columns, sortCol, style)
-	return nil
+	return contrib.TableRenderer{
+		Theme:      theme,
+		Style:      style,
+		Width:      int(params.width),
+		Columns:    columns,
+		SortBy:     params.sort,
+		Hyperlinks: hyperlinks,
+	}, nil
+}
+
+// useHyperlinks resolves the --hyperlinks option. auto enables hyperlinks
+// on terminals which support colors, as those are the ones that commonly
+// understand OSC 8 too.
+func useHyperlinks(opt string, isTerminal bool) (bool, error) {
+	switch opt {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		return isTerminal && termenv.EnvColorProfile() != termenv.Ascii && os.Getenv("TERM") != "dumb", nil
+	default:
+		return false, fmt.Errorf("unknown hyperlinks option: %s (valid: auto, always, never)", opt)
+	}
 }
 
 func defaultStyleName() string {
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// executeCommand runs the command line args without a config file and
+// returns what was written to stdout and stderr. The flags are reset
+// afterwards, so that the next run starts from the defaults.
+func executeCommand(t *testing.T, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	saved, savedConfig, savedLoaded := params, config, loadedReport
+	savedCheck, savedServe, savedExport := checkParams, serveParams, exportParams
+	defer func() {
+		params, config, loadedReport = saved, savedConfig, savedLoaded
+		checkParams, serveParams, exportParams = savedCheck, savedServe, savedExport
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		resetChanged(rootCmd)
+	}()
+	params.config = ""
+
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	savedStdout, savedStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outW, errW
+	var outB, errB bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(&outB, outR)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(&errB, errR)
+		done <- struct{}{}
+	}()
+
+	rootCmd.SetArgs(args)
+	rootCmd.SetOut(errW)
+	rootCmd.SetErr(errW)
+	err = rootCmd.Execute()
+
+	os.Stdout, os.Stderr = savedStdout, savedStderr
+	outW.Close()
+	errW.Close()
+	<-done
+	<-done
+	return outB.String(), errB.String(), err
+}
+
+// resetChanged marks every flag of c and its subcommands as not given.
+func resetChanged(c *cobra.Command) {
+	reset := func(f *pflag.Flag) { f.Changed = false }
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetChanged(sub)
+	}
+}
+
+// writeTestReport writes a JSON report of the contributions to dir and
+// returns its path.
+func writeTestReport(t *testing.T, dir string, contributions []contrib.Contribution) string {
+	t.Helper()
+	path := filepath.Join(dir, "report.json")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := contrib.NewReport("alice", []string{"github"}, "test", contributions).WriteJSON(f); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testContributions() []contrib.Contribution {
+	at := func(year int) time.Time { return time.Date(year, 3, 1, 0, 0, 0, 0, time.UTC) }
+	merged := at(2021).Add(48 * time.Hour)
+	return []contrib.Contribution{
+		{Type: contrib.Issue, Number: 1, Title: "Crash on start", Repo: "a/a", CreatedAt: at(2020), URL: "https://github.com/a/a/issues/1"},
+		{Type: contrib.PullRequest, Number: 2, Title: "Fix the crash", Repo: "a/a", CreatedAt: at(2021), Closed: true, Merged: true, ClosedAt: &merged, MergedAt: &merged, URL: "https://github.com/a/a/pull/2"},
+		{Type: contrib.PullRequest, Number: 3, Title: "Add docs", Repo: "b/b", CreatedAt: at(2021), URL: "https://github.com/b/b/pull/3"},
+	}
+}
+
+func TestDeprecatedInvocations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	report := writeTestReport(t, dir, testContributions())
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantOut    string
+		wantStderr string
+		wantErr    string
+	}{
+		{name: "list", args: []string{"--from", report}, wantOut: "Fix the crash", wantStderr: `use "list"`},
+		{name: "summary", args: []string{"--summary", "--from", report}, wantOut: "Your yearly contribution", wantStderr: `use "summary year"`},
+		{name: "repo summary", args: []string{"--summary", "--repo", "--from", report}, wantOut: "Your 2 contributed projects", wantStderr: `use "summary repo"`},
+		{name: "json", args: []string{"--json", "--from", report}, wantOut: `"schema_version"`, wantStderr: `use "export --format json"`},
+		{name: "repo without summary", args: []string{"--repo", "--from", report}, wantErr: "--repo requires --summary"},
+		{name: "json with summary", args: []string{"--json", "--summary", "--from", report}, wantErr: "--json cannot be combined with --summary"},
+		// scripts rely on the error of the invocation without an account
+		{name: "no account", args: []string{}, wantErr: "account name is not specified"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, stderr, err := executeCommand(t, append([]string{"--width", "120"}, tt.args...)...)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(out, tt.wantOut) {
+				t.Errorf("stdout = %q, want it to contain %q", out, tt.wantOut)
+			}
+			if !strings.Contains(stderr, tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestDeprecatedJSONMatchesExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	report := writeTestReport(t, dir, testContributions())
+
+	deprecated, _, err := executeCommand(t, "--json", "--from", report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, _, err := executeCommand(t, "export", "--format", "json", "--from", report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var a, b contrib.Report
+	if err := json.Unmarshal([]byte(deprecated), &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(exported), &b); err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Items) != 3 || len(b.Items) != len(a.Items) {
+		t.Errorf("--json has %d items and export %d, want 3", len(a.Items), len(b.Items))
+	}
+}
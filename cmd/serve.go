@@ -0,0 +1,386 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+)
+
+var serveParams struct {
+	listen         string
+	accounts       []string
+	refresh        time.Duration
+	includePrivate bool
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "serve a JSON API and a dashboard of the accounts' contributions",
+	Long: `Serve a JSON API and an HTML dashboard of the accounts' contributions.
+
+The contributions of every --account are fetched in the background every
+--refresh and kept in memory.
+
+  GET /                                                  dashboard
+  GET /accounts/{name}                                   dashboard of an account
+  GET /api/accounts/{name}/contributions                 contributions as JSON
+  GET /api/accounts/{name}/summary?group=year|repo|kind  summaries as JSON
+  GET /metrics                                           OpenMetrics gauges
+  GET /healthz                                           health check
+
+Only contributions to public repos are served, unless --include-private is
+given. With --redact, the contributions are redacted when they are fetched,
+and --redact aggregate turns off the contributions endpoint.
+
+Searches wait for the GitHub rate limit to reset, so a refresh may take
+longer than usual when many accounts are served.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(serveParams.accounts) == 0 {
+			return errors.New("account name is not specified")
+		}
+		if serveParams.refresh <= 0 {
+			return fmt.Errorf("--refresh must be positive, got %s", serveParams.refresh)
+		}
+		if err := redactor().Validate(); err != nil {
+			return err
+		}
+		if err := setToken(); err != nil {
+			return err
+		}
+		client, err := contrib.NewClient(context.Background(), params.token, params.host)
+		if err != nil {
+			return err
+		}
+
+		s := newServer(client, serveParams.accounts)
+		return s.run(serveParams.listen, serveParams.refresh)
+	},
+}
+
+// accountData is the last fetch result of an account.
+type accountData struct {
+	contributions []contrib.Contribution
+	fetchedAt     time.Time
+	err           error
+}
+
+type server struct {
+	client   *contrib.Client
+	accounts []string
+
+	mu   sync.RWMutex
+	data map[string]accountData
+}
+
+func newServer(client *contrib.Client, accounts []string) *server {
+	return &server{
+		client:   client,
+		accounts: accounts,
+		data:     make(map[string]accountData),
+	}
+}
+
+// run serves until SIGTERM or SIGINT and then shuts down gracefully.
+func (s *server) run(addr string, refresh time.Duration) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.refreshLoop(ctx, refresh)
+
+	srv := &http.Server{Addr: addr, Handler: s.handler()}
+	errc := make(chan error, 1)
+	go func() {
+		log.Printf("listening on %s", addr)
+		errc <- srv.ListenAndServe()
+	}()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGTERM, os.Interrupt)
+	select {
+	case err := <-errc:
+		return err
+	case <-sig:
+	}
+
+	log.Print("shutting down")
+	cancel()
+	sctx, scancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer scancel()
+	return srv.Shutdown(sctx)
+}
+
+// refreshLoop fetches every account now and then every interval. Accounts
+// are fetched one after another to stay within the API rate limit.
+func (s *server) refreshLoop(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		for _, account := range s.accounts {
+			f := contrib.GitHubFetcher{Client: s.client, Account: account, Reviews: params.reviews, ResponseTimes: params.responseTimes}
+			c, err := f.Fetch(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if !serveParams.includePrivate {
+				c = publicOnly(c)
+			}
+			classifier().Classify(c)
+			scoreWeights().Assign(c)
+			c = redactor().Redact(c)
+			if err != nil {
+				log.Printf("failed to fetch %s: %s", account, err)
+			}
+
+			s.mu.Lock()
+			d := s.data[account]
+			d.err = err
+			if err == nil {
+				d.contributions = c
+				d.fetchedAt = time.Now()
+			}
+			s.data[account] = d
+			s.mu.Unlock()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// publicOnly returns the contributions which are not to private repos.
+func publicOnly(contributions []contrib.Contribution) []contrib.Contribution {
+	var public []contrib.Contribution
+	for _, c := range contributions {
+		if !c.Private {
+			public = append(public, c)
+		}
+	}
+	return public
+}
+
+// account returns the cached data of a served account.
+func (s *server) account(name string) (accountData, bool) {
+	for _, a := range s.accounts {
+		if strings.EqualFold(a, name) {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+			return s.data[a], true
+		}
+	}
+	return accountData{}, false
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	mux.HandleFunc("/api/accounts/", s.handleAPI)
+	mux.HandleFunc("/accounts/", s.handleAccountPage)
+	mux.HandleFunc("/", s.handleIndex)
+	return mux
+}
+
+// handleAPI serves /api/accounts/{name}/contributions and
+// /api/accounts/{name}/summary.
+func (s *server) handleAPI(w http.ResponseWriter, r *http.Request) {
+	p := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/accounts/"), "/")
+	if len(p) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	d, ok := s.account(p[0])
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, "unknown account: "+p[0])
+		return
+	}
+	if d.fetchedAt.IsZero() {
+		msg := "not fetched yet"
+		if d.err != nil {
+			msg = d.err.Error()
+		}
+		writeJSONError(w, http.StatusServiceUnavailable, msg)
+		return
+	}
+
+	switch p[1] {
+	case "contributions":
+		if err := requireItems(); err != nil {
+			writeJSONError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		writeJSON(w, s.report(p[0], d))
+	case "summary":
+		group := r.URL.Query().Get("group")
+		if group == "" {
+			group = "year"
+		}
+		summaries, err := contrib.SummarizeBy(d.contributions, group)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"account":    p[0],
+			"group":      group,
+			"fetched_at": d.fetchedAt.UTC(),
+			"summaries":  summaries,
+		})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleMetrics serves the counts of every fetched account.
+func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	var reports []contrib.Report
+	s.mu.RLock()
+	for _, a := range s.accounts {
+		d := s.data[a]
+		if d.fetchedAt.IsZero() {
+			continue
+		}
+		reports = append(reports, s.report(a, d))
+	}
+	s.mu.RUnlock()
+
+	w.Header().Set("Content-Type", contrib.OpenMetricsContentType)
+	if err := contrib.WriteOpenMetrics(w, reports...); err != nil {
+		log.Print(err)
+	}
+}
+
+// report returns the fetched data of an account as a Report.
+func (s *server) report(account string, d accountData) contrib.Report {
+	return contrib.Report{
+		SchemaVersion: contrib.ReportSchemaVersion,
+		ToolVersion:   version,
+		Account:       account,
+		Sources:       []string{"github"},
+		FetchedAt:     d.fetchedAt.UTC(),
+		Items:         d.contributions,
+	}
+}
+
+func (s *server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if len(s.accounts) == 1 {
+		http.Redirect(w, r, "/accounts/"+s.accounts[0], http.StatusFound)
+		return
+	}
+	renderPage(w, indexTemplate, s.accounts)
+}
+
+func (s *server) handleAccountPage(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/accounts/")
+	d, ok := s.account(name)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	renderPage(w, accountTemplate, map[string]interface{}{
+		"Account":   name,
+		"FetchedAt": d.fetchedAt,
+		"Err":       d.err,
+		"Total":     len(d.contributions),
+		"Years":     contrib.SummarizeByYear(d.contributions),
+		"Repos":     contrib.Summarize(d.contributions, contrib.ByRepo),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+func renderPage(w http.ResponseWriter, t *template.Template, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := t.Execute(w, data); err != nil {
+		log.Print(err)
+	}
+}
+
+var templateFuncs = template.FuncMap{
+	"percent": func(f float64) string { return fmt.Sprintf("%.1f", f*100) },
+	"barpx":   func(f float64) int { return int(f * 200) },
+	"dict": func(kv ...interface{}) map[string]interface{} {
+		m := make(map[string]interface{})
+		for i := 0; i+1 < len(kv); i += 2 {
+			m[kv[i].(string)] = kv[i+1]
+		}
+		return m
+	},
+}
+
+const pageHeader = `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta http-equiv="refresh" content="300"><title>oss-contribution-checker</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+.bar { background: #A8CC8C; height: 0.8em; display: inline-block; }
+</style></head><body>
+`
+
+var indexTemplate = template.Must(template.New("index").Parse(pageHeader + `<h1>Accounts</h1>
+<ul>{{range .}}<li><a href="/accounts/{{.}}">{{.}}</a></li>{{end}}</ul>
+</body></html>`))
+
+var accountTemplate = template.Must(template.New("account").Funcs(templateFuncs).Parse(pageHeader + `<h1>{{.Account}}</h1>
+{{if .Err}}<p>last refresh failed: {{.Err}}</p>{{end}}
+{{if .FetchedAt.IsZero}}<p>not fetched yet, reload in a moment</p>{{else}}
+<p>{{.Total}} contributions, fetched at {{.FetchedAt.Format "2006-01-02 15:04:05"}}</p>
+<h2>Your yearly contribution</h2>
+{{template "summary" dict "Name" "Year" "Rows" .Years}}
+<h2>Your {{len .Repos}} contributed projects</h2>
+{{template "summary" dict "Name" "Repo" "Rows" .Repos}}
+{{end}}
+</body></html>
+{{define "summary"}}<table>
+<tr><th>{{.Name}}</th><th>issue count</th><th>PR count</th><th>commit count</th><th>other count</th><th>issue%</th><th>PR%</th></tr>
+{{range .Rows}}<tr><td>{{.Key}}</td><td>{{.Issues}}</td><td>{{.PRs}}</td><td>{{.Commits}}</td><td>{{.Others}}</td>
+<td><span class="bar" style="width: {{barpx .IssuePercent}}px"></span> {{percent .IssuePercent}}%</td>
+<td><span class="bar" style="width: {{barpx .PRPercent}}px"></span> {{percent .PRPercent}}%</td></tr>
+{{end}}</table>{{end}}`))
+
+func init() {
+	serveCmd.Flags().StringVar(&params.token, "token", "", "github token (prefer GITHUB_TOKEN or --token-stdin)")
+	serveCmd.Flags().BoolVar(&params.tokenStdin, "token-stdin", false, "read github token from stdin")
+	serveCmd.Flags().StringSliceVar(&serveParams.accounts, "account", nil, "github accounts to serve")
+	serveCmd.Flags().BoolVar(&params.reviews, "reviews", false, "also fetch the pull requests the accounts reviewed")
+	serveCmd.Flags().BoolVar(&params.responseTimes, "response-times", false, "also fetch the first maintainer response of every issue and PR")
+	serveCmd.Flags().StringVar(&serveParams.listen, "listen", ":8080", "address to listen on")
+	serveCmd.Flags().DurationVar(&serveParams.refresh, "refresh", 30*time.Minute, "interval between refreshes")
+	serveCmd.Flags().BoolVar(&serveParams.includePrivate, "include-private", false, "also serve contributions to private repos")
+	addRedactFlags(serveCmd)
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+)
+
+func TestServeRefresh(t *testing.T) {
+	for _, refresh := range []string{"0", "-1m"} {
+		_, _, err := executeCommand(t, "serve", "--account", "alice", "--refresh", refresh)
+		if err == nil || !strings.Contains(err.Error(), "--refresh must be positive") {
+			t.Errorf("serve --refresh %s: error = %v, want --refresh must be positive", refresh, err)
+		}
+	}
+}
+
+func TestPublicOnly(t *testing.T) {
+	public := contrib.Contribution{Type: contrib.PullRequest, Repo: "a/public"}
+	private := contrib.Contribution{Type: contrib.PullRequest, Repo: "a/private", Private: true}
+	got := publicOnly([]contrib.Contribution{public, private})
+	if want := []contrib.Contribution{public}; !reflect.DeepEqual(got, want) {
+		t.Errorf("publicOnly() = %+v, want %+v", got, want)
+	}
+}
+
+func TestServerHandler(t *testing.T) {
+	saved := params
+	defer func() { params = saved }()
+
+	s := newServer(nil, []string{"alice", "bob"})
+	s.data["alice"] = accountData{contributions: testContributions(), fetchedAt: time.Now()}
+	h := s.handler()
+
+	tests := []struct {
+		name     string
+		path     string
+		redact   string
+		wantCode int
+		wantBody string
+	}{
+		{name: "healthz", path: "/healthz", wantCode: http.StatusOK, wantBody: "ok"},
+		{name: "contributions", path: "/api/accounts/alice/contributions", wantCode: http.StatusOK, wantBody: `"Fix the crash"`},
+		{name: "summary", path: "/api/accounts/Alice/summary?group=repo", wantCode: http.StatusOK, wantBody: `"key": "a/a"`},
+		{name: "unknown group", path: "/api/accounts/alice/summary?group=color", wantCode: http.StatusBadRequest},
+		{name: "unknown account", path: "/api/accounts/carol/summary", wantCode: http.StatusNotFound},
+		{name: "not fetched yet", path: "/api/accounts/bob/summary", wantCode: http.StatusServiceUnavailable, wantBody: "not fetched yet"},
+		{name: "aggregate only", path: "/api/accounts/alice/contributions", redact: contrib.RedactAggregate, wantCode: http.StatusForbidden},
+		{name: "metrics", path: "/metrics", wantCode: http.StatusOK, wantBody: `account="alice"`},
+		{name: "dashboard", path: "/accounts/alice", wantCode: http.StatusOK, wantBody: "Your 2 contributed projects"},
+		{name: "index", path: "/", wantCode: http.StatusOK, wantBody: `href="/accounts/bob"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params.redact = tt.redact
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+			if w.Code != tt.wantCode {
+				t.Errorf("GET %s = %d, want %d", tt.path, w.Code, tt.wantCode)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("GET %s = %q, want it to contain %q", tt.path, w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestServerSummaryJSON(t *testing.T) {
+	s := newServer(nil, []string{"alice"})
+	s.data["alice"] = accountData{contributions: testContributions(), fetchedAt: time.Now()}
+	w := httptest.NewRecorder()
+	s.handler().ServeHTTP(w, httptest.NewRequest("GET", "/api/accounts/alice/summary", nil))
+
+	var got struct {
+		Group     string            `json:"group"`
+		Summaries []contrib.Summary `json:"summaries"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := contrib.SummarizeByYear(testContributions())
+	if got.Group != "year" || !reflect.DeepEqual(got.Summaries, want) {
+		t.Errorf("summary = %+v, want the yearly summaries %+v", got, want)
+	}
+}
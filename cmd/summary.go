@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+)
+
+var slowestParams struct {
+	top int
+}
+
+var summaryRepoParams struct {
+	by string
+}
+
+var summaryFoundationParams struct {
+	by string
+}
+
+// maxUnmatched is the number of unmatched repos listed by summary
+// foundation.
+const maxUnmatched = 10
+
+// groupsByRepoInfo reports whether summary repo groups by the metadata of
+// the repos.
+func groupsByRepoInfo() bool {
+	return summaryRepoParams.by != "" && summaryRepoParams.by != "repo"
+}
+
+var summaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "show contribution counts grouped by year, repo, kind or foundation",
+}
+
+var summaryYearCmd = &cobra.Command{
+	Use:   "year",
+	Short: "show contribution counts per year",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contributions, err := retrieveData()
+		if err != nil {
+			return err
+		}
+		return showTable(contributions, "year")
+	},
+}
+
+var summaryRepoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "show contribution counts per repo",
+	Long: `Show contribution counts per repo, or with --by per star tier, license,
+archived or fork flag of the repos. Grouping by the metadata of the repos
+looks it up like --repo-info.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch summaryRepoParams.by {
+		case "repo", "stars", "license", "archived", "fork":
+		default:
+			return fmt.Errorf("unknown grouping key: %s (valid: repo, stars, license, archived, fork)", summaryRepoParams.by)
+		}
+		contributions, err := retrieveData()
+		if err != nil {
+			return err
+		}
+		return showTable(contributions, summaryRepoParams.by)
+	},
+}
+
+var summaryKindCmd = &cobra.Command{
+	Use:   "kind",
+	Short: "show contribution counts per kind, such as fix, feature or docs",
+	Long: `Show contribution counts per kind. Ledger entries keep their kind, issues,
+PRs and commits are classified by the label mappings of the config file and
+then by conventional commit prefixes of the title such as "fix:".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contributions, err := retrieveData()
+		if err != nil {
+			return err
+		}
+		return showTable(contributions, "kind")
+	},
+}
+
+var summaryFoundationCmd = &cobra.Command{
+	Use:   "foundation",
+	Short: "show contribution counts per foundation or ecosystem",
+	Long: `Show contribution counts per foundation, or with --by maturity per project
+maturity level, as assigned by the catalog file given with --catalog:
+
+  projects:
+  - foundation: CNCF
+    maturity: graduated
+    repos: [kubernetes/*, etcd-io/etcd]
+  - foundation: Apache
+    orgs: [apache]
+  - foundation: CNCF
+    topics: [cncf]
+
+Repo patterns are matched first, then orgs and then topics, which are looked
+up like --repo-info. The repos no entry matched are listed below the table.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch summaryFoundationParams.by {
+		case "foundation", "maturity":
+		default:
+			return fmt.Errorf("unknown grouping key: %s (valid: foundation, maturity)", summaryFoundationParams.by)
+		}
+		if params.catalog == "" {
+			return errors.New("catalog file is not specified")
+		}
+		contributions, err := retrieveData()
+		if err != nil {
+			return err
+		}
+		if err := showTable(contributions, summaryFoundationParams.by); err != nil {
+			return err
+		}
+		if unmatched := contrib.Unmatched(contributions); len(unmatched) > 0 {
+			fmt.Printf("unmatched repos (%d): %s\n", len(unmatched), shortList(unmatched, maxUnmatched))
+		}
+		return nil
+	},
+}
+
+// shortList joins the first n of s and how many were left out.
+func shortList(s []string, n int) string {
+	if len(s) <= n {
+		return strings.Join(s, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(s[:n], ", "), len(s)-n)
+}
+
+var summarySlowestCmd = &cobra.Command{
+	Use:   "slowest",
+	Short: "show the repos taking the longest to merge your PRs",
+	Long: `Show the repos with the longest median time from opening to merging your
+PRs, with the 90th percentile, the median time to close PRs without merging
+and the median time until a maintainer first responded (needs
+--response-times).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contributions, err := retrieveData()
+		if err != nil {
+			return err
+		}
+		r, err := newTableRenderer()
+		if err != nil {
+			return err
+		}
+		return r.RenderSlowest(os.Stdout, contrib.SlowestRepos(contributions, slowestParams.top))
+	},
+}
+
+func init() {
+	addSourceFlags(summaryYearCmd)
+	addRenderFlags(summaryYearCmd)
+	addSourceFlags(summaryRepoCmd)
+	addRenderFlags(summaryRepoCmd)
+	summaryRepoCmd.Flags().StringVar(&summaryRepoParams.by, "by", "repo", "group by: repo, stars, license, archived, fork")
+	summaryCmd.AddCommand(summaryYearCmd)
+	addSourceFlags(summaryKindCmd)
+	addRenderFlags(summaryKindCmd)
+	summaryCmd.AddCommand(summaryRepoCmd)
+	summaryCmd.AddCommand(summaryKindCmd)
+	addSourceFlags(summaryFoundationCmd)
+	addRenderFlags(summaryFoundationCmd)
+	summaryFoundationCmd.Flags().StringVar(&summaryFoundationParams.by, "by", "foundation", "group by: foundation, maturity")
+	summaryCmd.AddCommand(summaryFoundationCmd)
+	addSourceFlags(summarySlowestCmd)
+	addRenderFlags(summarySlowestCmd)
+	summarySlowestCmd.Flags().IntVar(&slowestParams.top, "top", 10, "number of repos to show, all if 0")
+	summaryCmd.AddCommand(summarySlowestCmd)
+	rootCmd.AddCommand(summaryCmd)
+}
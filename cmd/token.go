@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+type Token struct {
+	GithubToken string `yaml:"github_token"`
+}
+
+// tokenSource is one place a github token can be read from. read returns an
+// empty token and no error if the source is not available.
+type tokenSource struct {
+	name string
+	read func() (string, error)
+}
+
+// tokenSources returns the token sources in the order they are tried.
+func tokenSources() []tokenSource {
+	return []tokenSource{
+		{name: "--token", read: func() (string, error) { return params.token, nil }},
+		{name: "--token-stdin", read: readTokenStdin},
+		{name: "GITHUB_TOKEN", read: func() (string, error) { return os.Getenv("GITHUB_TOKEN"), nil }},
+		{name: "GH_TOKEN", read: func() (string, error) { return os.Getenv("GH_TOKEN"), nil }},
+		{name: "encrypted token store", read: readTokenStore},
+		{name: "gh hosts.yml", read: readGhHostsToken},
+		{name: "git credential helper", read: readGitCredentialToken},
+		{name: "token.txt", read: readTokenFile},
+		{name: "~/.git-neco.yml", read: readGitNecoToken},
+	}
+}
+
+// tokenSourceName is the source setToken took the token from, empty until
+// it has been resolved.
+var tokenSourceName string
+
+// setToken resolves the github token from the first available source. It
+// is resolved once per run, later calls keep the token. A locked token store
+// which cannot be unlocked without a terminal is skipped with a warning.
+func setToken() error {
+	if tokenSourceName != "" {
+		return nil
+	}
+	var tried []string
+	for _, src := range tokenSources() {
+		token, err := src.read()
+		if errors.Is(err, errCannotPrompt) {
+			fmt.Fprintf(os.Stderr, "skipping %s: %s\n", src.name, err)
+			tried = append(tried, src.name)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read token from %s: %w", src.name, err)
+		}
+		token = strings.TrimSpace(token)
+		if token == "" {
+			tried = append(tried, src.name)
+			continue
+		}
+		params.token = token
+		tokenSourceName = src.name
+		fmt.Fprintf(os.Stderr, "using github token from %s\n", src.name)
+		return nil
+	}
+
+	return fmt.Errorf("no github token found for %s (tried: %s)", params.host, strings.Join(tried, ", "))
+}
+
+// readTokenStdin reads the token from the first line of stdin.
+func readTokenStdin() (string, error) {
+	if !params.tokenStdin {
+		return "", nil
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("no token on stdin")
+	}
+	return line, nil
+}
+
+// ghConfigDir returns the config directory of the gh CLI.
+func ghConfigDir() (string, error) {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh"), nil
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI"), nil
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "gh"), nil
+}
+
+// readGhHostsToken reads the oauth token gh stored for the host. Recent gh
+// versions keep the token in the OS keyring instead, which is covered by the
+// git credential helper.
+func readGhHostsToken() (string, error) {
+	dir, err := ghConfigDir()
+	if err != nil {
+		return "", nil
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, "hosts.yml"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(b, &hosts); err != nil {
+		return "", err
+	}
+	return hosts[params.host].OAuthToken, nil
+}
+
+// readGitCredentialToken asks the configured git credential helper for the
+// host's password without prompting.
+func readGitCredentialToken() (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", nil
+	}
+	c := exec.Command("git", "credential", "fill")
+	c.Stdin = strings.NewReader("protocol=https\nhost=" + params.host + "\n\n")
+	c.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=")
+	out, err := c.Output()
+	if err != nil {
+		// no helper configured or no credential stored
+		return "", nil
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		if strings.HasPrefix(sc.Text(), "password=") {
+			return strings.TrimPrefix(sc.Text(), "password="), nil
+		}
+	}
+	return "", nil
+}
+
+func readTokenFile() (string, error) {
+	b, err := ioutil.ReadFile("token.txt")
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	warnPlaintextToken("token.txt")
+	return string(b), nil
+}
+
+func readGitNecoToken() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", nil
+	}
+	b, err := ioutil.ReadFile(home + "/.git-neco.yml")
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	var t Token
+	err = yaml.Unmarshal(b, &t)
+	if err != nil {
+		return "", err
+	}
+	if t.GithubToken != "" {
+		warnPlaintextToken(home + "/.git-neco.yml")
+	}
+	return t.GithubToken, nil
+}
+
+// warnPlaintextToken warns if a plaintext token file can be read by other
+// users.
+func warnPlaintextToken(path string) {
+	fi, err := os.Stat(path)
+	if err != nil || fi.Mode().Perm()&0004 == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "warning: %s is world-readable (mode %s), use \"oss-contribution-checker auth login\" to store the token encrypted\n", path, fi.Mode().Perm())
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/binoue/oss-contribution-checker/contrib"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/openpgp"
+)
+
+var verifyParams struct {
+	signature string
+	keyring   string
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify report.json",
+	Short: "verify a report signed by export --sign",
+	Long: `Verify the detached OpenPGP signature of a report written by
+"export --format json --sign" against a public key ring, and recompute the
+year, repo and kind summaries from the items of the report to detect any
+inconsistency.
+
+  export --format json --sign --signing-key priv.asc --signature report.json.asc > report.json
+  verify report.json --signature report.json.asc --keyring pub.asc
+
+The signature can be checked with gpg --verify too.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if verifyParams.keyring == "" {
+			return errors.New("--keyring is not specified")
+		}
+		sigPath := verifyParams.signature
+		if sigPath == "" {
+			sigPath = args[0] + ".asc"
+		}
+		keyring, err := readKeyRing(verifyParams.keyring)
+		if err != nil {
+			return err
+		}
+		report, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		signature, err := ioutil.ReadFile(sigPath)
+		if err != nil {
+			return err
+		}
+
+		signer, r, err := contrib.VerifyDetached(report, signature, keyring)
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+		fmt.Printf("good signature from %s (key %X)\n", identityNames(signer), signer.PrimaryKey.Fingerprint)
+		fmt.Printf("account:      %s\n", r.Account)
+		fmt.Printf("queries:      %s\n", strings.Join(r.Queries, "; "))
+		fmt.Printf("fetched at:   %s\n", r.FetchedAt.Format(time.RFC3339))
+		fmt.Printf("tool version: %s\n", r.ToolVersion)
+		if len(r.Transformations) > 0 {
+			fmt.Printf("transformed:  %s\n", strings.Join(r.Transformations, "; "))
+		}
+		fmt.Printf("items:        %d\n", len(r.Items))
+		if err := r.CheckSummaries(); err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+		fmt.Println("summaries match the items")
+		return nil
+	},
+}
+
+// readSigningKey reads the first private key of a key ring and decrypts it
+// with a passphrase if needed.
+func readSigningKey(path string) (*openpgp.Entity, error) {
+	keyring, err := readKeyRing(path)
+	if err != nil {
+		return nil, err
+	}
+	var signer *openpgp.Entity
+	for _, e := range keyring {
+		if e.PrivateKey != nil {
+			signer = e
+			break
+		}
+	}
+	if signer == nil {
+		return nil, fmt.Errorf("%s has no private key", path)
+	}
+
+	if signer.PrivateKey.Encrypted {
+		passphrase, err := readPassphrase("passphrase for " + path + ": ")
+		if err != nil {
+			return nil, err
+		}
+		if err := signer.PrivateKey.Decrypt(passphrase); err != nil {
+			return nil, errors.New("wrong passphrase")
+		}
+	}
+	return signer, nil
+}
+
+func identityNames(e *openpgp.Entity) string {
+	var names []string
+	for name := range e.Identities {
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	verifyCmd.Flags().StringVar(&verifyParams.signature, "signature", "", "armored or binary detached signature (default: the report path with .asc)")
+	verifyCmd.Flags().StringVar(&verifyParams.keyring, "keyring", "", "OpenPGP public key ring to verify against")
+	rootCmd.AddCommand(verifyCmd)
+}
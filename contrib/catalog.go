@@ -0,0 +1,133 @@
+package contrib
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Catalog maps repos to the foundations or ecosystems they belong to, such
+// as CNCF or Apache.
+type Catalog struct {
+	Projects []CatalogEntry `yaml:"projects"`
+}
+
+// CatalogEntry assigns a foundation and a maturity level to the repos
+// matching any of its patterns.
+type CatalogEntry struct {
+	Foundation string `yaml:"foundation"`
+	// Maturity is the project level, e.g. "graduated" or "incubating".
+	Maturity string `yaml:"maturity"`
+	// Repos are "owner/repo" patterns like "kubernetes/*", Orgs are owners
+	// and Topics are GitHub topics, see Enrich.
+	Repos  []string `yaml:"repos"`
+	Orgs   []string `yaml:"orgs"`
+	Topics []string `yaml:"topics"`
+}
+
+// LoadCatalog parses a YAML catalog file and validates it.
+func LoadCatalog(path string) (Catalog, error) {
+	var catalog Catalog
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return catalog, err
+	}
+	if err := yaml.UnmarshalStrict(b, &catalog); err != nil {
+		return catalog, fmt.Errorf("failed to parse catalog %s: %w", path, err)
+	}
+	if err := catalog.validate(); err != nil {
+		return catalog, fmt.Errorf("invalid catalog %s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+func (c Catalog) validate() error {
+	var problems []string
+	for i, e := range c.Projects {
+		prefix := fmt.Sprintf("project %d", i+1)
+		if e.Foundation == "" {
+			problems = append(problems, prefix+": foundation is empty")
+		}
+		if len(e.Repos) == 0 && len(e.Orgs) == 0 && len(e.Topics) == 0 {
+			problems = append(problems, prefix+": no repos, orgs or topics")
+		}
+		for _, p := range e.Repos {
+			if _, err := path.Match(p, ""); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid repo pattern %q", prefix, p))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// HasTopics reports whether any entry matches topics, which need the
+// metadata of the repos.
+func (c Catalog) HasTopics() bool {
+	for _, e := range c.Projects {
+		if len(e.Topics) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Assign sets Foundation and Maturity of every contribution whose repo is in
+// the catalog. Repo patterns take precedence over orgs and orgs over topics,
+// so that a single project can have another maturity than the rest of its
+// org. Within each, the first matching entry wins.
+func (c Catalog) Assign(contributions []Contribution) {
+	for i := range contributions {
+		if e, ok := c.lookup(contributions[i]); ok {
+			contributions[i].Foundation = e.Foundation
+			contributions[i].Maturity = e.Maturity
+		}
+	}
+}
+
+func (c Catalog) lookup(con Contribution) (CatalogEntry, bool) {
+	for _, e := range c.Projects {
+		if matchRepo(e.Repos, con.Repo) {
+			return e, true
+		}
+	}
+	owner := strings.SplitN(con.Repo, "/", 2)[0]
+	for _, e := range c.Projects {
+		for _, o := range e.Orgs {
+			if strings.EqualFold(o, owner) {
+				return e, true
+			}
+		}
+	}
+	if con.RepoInfo != nil {
+		for _, e := range c.Projects {
+			if len(intersect(con.RepoInfo.Topics, e.Topics)) > 0 {
+				return e, true
+			}
+		}
+	}
+	return CatalogEntry{}, false
+}
+
+// Unmatched returns the sorted repos of the contributions without a
+// foundation, so that they can be added to the catalog.
+func Unmatched(contributions []Contribution) []string {
+	seen := make(map[string]bool)
+	var repos []string
+	for _, c := range contributions {
+		if c.Foundation != "" || c.Repo == "" || seen[c.Repo] {
+			continue
+		}
+		seen[c.Repo] = true
+		repos = append(repos, c.Repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
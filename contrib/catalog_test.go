@@ -0,0 +1,113 @@
+package contrib
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCatalogAssign(t *testing.T) {
+	catalog := Catalog{Projects: []CatalogEntry{
+		{Foundation: "CNCF", Maturity: "graduated", Orgs: []string{"kubernetes"}},
+		{Foundation: "CNCF", Maturity: "sandbox", Repos: []string{"kubernetes/kompose"}},
+		{Foundation: "Apache", Topics: []string{"apache"}},
+		{Foundation: "CNCF", Maturity: "incubating", Repos: []string{"kubernetes/k*"}, Topics: []string{"cloud-native"}},
+		{Foundation: "Other", Orgs: []string{"Kubernetes"}},
+	}}
+
+	tests := []struct {
+		name           string
+		contribution   Contribution
+		wantFoundation string
+		wantMaturity   string
+	}{
+		{
+			name:           "repo pattern before org",
+			contribution:   Contribution{Repo: "kubernetes/kompose"},
+			wantFoundation: "CNCF",
+			wantMaturity:   "sandbox",
+		},
+		{
+			name:           "first matching repo pattern",
+			contribution:   Contribution{Repo: "kubernetes/kubectl"},
+			wantFoundation: "CNCF",
+			wantMaturity:   "incubating",
+		},
+		{
+			name:           "first matching org case-insensitively",
+			contribution:   Contribution{Repo: "Kubernetes/website"},
+			wantFoundation: "CNCF",
+			wantMaturity:   "graduated",
+		},
+		{
+			name:           "org before topic",
+			contribution:   Contribution{Repo: "kubernetes/website", RepoInfo: &RepoInfo{Topics: []string{"apache"}}},
+			wantFoundation: "CNCF",
+			wantMaturity:   "graduated",
+		},
+		{
+			name:           "first matching topic",
+			contribution:   Contribution{Repo: "x/y", RepoInfo: &RepoInfo{Topics: []string{"cloud-native", "apache"}}},
+			wantFoundation: "Apache",
+		},
+		{
+			name:         "unmatched",
+			contribution: Contribution{Repo: "x/y"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contributions := []Contribution{tt.contribution}
+			catalog.Assign(contributions)
+			if c := contributions[0]; c.Foundation != tt.wantFoundation || c.Maturity != tt.wantMaturity {
+				t.Errorf("Assign() = %q, %q, want %q, %q", c.Foundation, c.Maturity, tt.wantFoundation, tt.wantMaturity)
+			}
+		})
+	}
+}
+
+func TestCatalogValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog Catalog
+		wantErr []string
+	}{
+		{
+			name:    "valid",
+			catalog: Catalog{Projects: []CatalogEntry{{Foundation: "CNCF", Repos: []string{"kubernetes/*"}}}},
+		},
+		{
+			name:    "no foundation",
+			catalog: Catalog{Projects: []CatalogEntry{{Orgs: []string{"kubernetes"}}}},
+			wantErr: []string{"project 1: foundation is empty"},
+		},
+		{
+			name:    "nothing to match",
+			catalog: Catalog{Projects: []CatalogEntry{{Foundation: "CNCF"}, {Foundation: "Apache"}}},
+			wantErr: []string{"project 1: no repos, orgs or topics", "project 2: no repos, orgs or topics"},
+		},
+		{
+			name:    "invalid pattern",
+			catalog: Catalog{Projects: []CatalogEntry{{Foundation: "CNCF", Repos: []string{"kubernetes/["}}}},
+			wantErr: []string{`project 1: invalid repo pattern "kubernetes/["`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.catalog.validate()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("validate() = nil, want %q", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("validate() = %v, want an error containing %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package contrib
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metrics maps the metric names usable in rules to what they count.
+var Metrics = map[string]func(Contribution) bool{
+	"contributions": func(c Contribution) bool { return true },
+	"issues":        func(c Contribution) bool { return c.Type == Issue },
+	"prs":           func(c Contribution) bool { return c.Type == PullRequest },
+	"merged_prs":    func(c Contribution) bool { return c.Type == PullRequest && c.Merged },
+	"reviews":       func(c Contribution) bool { return c.Type == Review },
+	"commits":       func(c Contribution) bool { return c.Type == Commit },
+	"ledger":        func(c Contribution) bool { return c.Type == Ledger },
+	// repos counts the distinct repositories of forge items, see
+	// Rule.Evaluate. Ledger projects and local clones are not repos
+	// contributed to.
+	"repos": func(c Contribution) bool { return c.Type == Issue || c.Type == PullRequest || c.Type == Review },
+}
+
+// metricStates maps the metrics counting contributions in a state to it,
+// so that they are windowed by the time the state was reached.
+var metricStates = map[string]string{
+	"merged_prs": "merged",
+}
+
+// MetricNames returns the names of Metrics in a stable order.
+func MetricNames() []string {
+	var names []string
+	for k := range Metrics {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var rulePattern = regexp.MustCompile(`^\s*([a-z_]+)(?:\s+in\s+([0-9]+)d)?\s*(>=|<=|==|!=|>|<)\s*([0-9]+)\s*$`)
+
+// Rule is a threshold on a metric, e.g. "merged_prs in 365d >= 3".
+type Rule struct {
+	Text   string
+	Metric string
+	// Days limits the metric to the contributions of the last days, all if 0.
+	Days  int
+	Op    string
+	Value int
+}
+
+// RuleResult is the outcome of a rule.
+type RuleResult struct {
+	Rule   string `json:"rule"`
+	Actual int    `json:"actual"`
+	Passed bool   `json:"passed"`
+}
+
+// ParseRule parses "<metric> [in <days>d] <op> <value>".
+func ParseRule(s string) (Rule, error) {
+	m := rulePattern.FindStringSubmatch(s)
+	if m == nil {
+		return Rule{}, fmt.Errorf("invalid rule %q, expected \"<metric> [in <days>d] <op> <value>\" like \"merged_prs in 365d >= 3\"", s)
+	}
+	if _, ok := Metrics[m[1]]; !ok {
+		return Rule{}, fmt.Errorf("invalid rule %q: unknown metric %s (valid: %s)", s, m[1], strings.Join(MetricNames(), ", "))
+	}
+	r := Rule{Text: strings.TrimSpace(s), Metric: m[1], Op: m[3]}
+	// the numbers are matched by the pattern already
+	r.Days, _ = strconv.Atoi(m[2])
+	r.Value, _ = strconv.Atoi(m[4])
+	return r, nil
+}
+
+// Evaluate checks the rule against the contributions as of now. The days of
+// a rule are counted back from now to the time of the event the metric
+// counts, e.g. the merge of merged_prs and the first review of reviews.
+func (r Rule) Evaluate(contributions []Contribution, now time.Time) RuleResult {
+	count := Metrics[r.Metric]
+	since := time.Time{}
+	if r.Days > 0 {
+		since = now.AddDate(0, 0, -r.Days)
+	}
+
+	actual := 0
+	repos := make(map[string]bool)
+	for _, c := range contributions {
+		t := c.eventTime(metricStates[r.Metric])
+		if t.Before(since) || t.After(now) || !count(c) {
+			continue
+		}
+		actual++
+		repos[c.Repo] = true
+	}
+	if r.Metric == "repos" {
+		actual = len(repos)
+	}
+
+	return RuleResult{Rule: r.Text, Actual: actual, Passed: compare(actual, r.Op, r.Value)}
+}
+
+func compare(a int, op string, b int) bool {
+	switch op {
+	case ">=":
+		return a >= b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case "<":
+		return a < b
+	case "==":
+		return a == b
+	default:
+		return a != b
+	}
+}
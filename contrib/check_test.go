@@ -0,0 +1,84 @@
+package contrib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    Rule
+		wantErr bool
+	}{
+		{
+			rule: "merged_prs in 365d >= 3",
+			want: Rule{Text: "merged_prs in 365d >= 3", Metric: "merged_prs", Days: 365, Op: ">=", Value: 3},
+		},
+		{
+			rule: "  repos>=2 ",
+			want: Rule{Text: "repos>=2", Metric: "repos", Op: ">=", Value: 2},
+		},
+		{
+			rule: "issues != 0",
+			want: Rule{Text: "issues != 0", Metric: "issues", Op: "!=", Value: 0},
+		},
+		{rule: "stars >= 3", wantErr: true},
+		{rule: "prs in 30 >= 1", wantErr: true},
+		{rule: "prs => 1", wantErr: true},
+		{rule: "prs >= -1", wantErr: true},
+		{rule: "prs >= 1, repos >= 2", wantErr: true},
+		{rule: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRule(tt.rule)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRule(%q) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRule(%q) = %+v, want %+v", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestRuleEvaluate(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	at := func(t time.Time) *time.Time { return &t }
+	contributions := []Contribution{
+		{Type: PullRequest, Repo: "a/a", Merged: true, CreatedAt: now.AddDate(0, -1, 0)},
+		{Type: PullRequest, Repo: "b/b", Merged: true, CreatedAt: now.AddDate(-2, 0, 0)},
+		// created before the window, merged inside it
+		{Type: PullRequest, Repo: "b/b", Merged: true, CreatedAt: now.AddDate(0, -14, 0), MergedAt: at(now.AddDate(0, -11, 0))},
+		// the PR was created long before the review
+		{Type: Review, Repo: "a/a", CreatedAt: now.AddDate(-2, 0, 0), ReviewedAt: at(now.AddDate(0, -1, 0))},
+		{Type: Issue, Repo: "a/a", CreatedAt: now.AddDate(0, -2, 0)},
+		{Type: Commit, Repo: "c/c", CreatedAt: now.AddDate(0, -1, 0)},
+		{Type: Ledger, Repo: "d/d", CreatedAt: now.AddDate(0, -1, 0)},
+	}
+	tests := []struct {
+		rule       string
+		wantActual int
+		wantPassed bool
+	}{
+		{rule: "merged_prs >= 2", wantActual: 3, wantPassed: true},
+		{rule: "merged_prs in 365d >= 2", wantActual: 2, wantPassed: true},
+		{rule: "merged_prs in 300d >= 2", wantActual: 1, wantPassed: false},
+		{rule: "prs in 365d >= 2", wantActual: 1, wantPassed: false},
+		{rule: "reviews in 90d == 1", wantActual: 1, wantPassed: true},
+		{rule: "contributions in 90d == 5", wantActual: 5, wantPassed: true},
+		// commits and ledger entries are not counted as repos
+		{rule: "repos >= 3", wantActual: 2, wantPassed: false},
+		{rule: "repos in 365d < 2", wantActual: 1, wantPassed: true},
+	}
+	for _, tt := range tests {
+		r, err := ParseRule(tt.rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := r.Evaluate(contributions, now)
+		if got.Actual != tt.wantActual || got.Passed != tt.wantPassed {
+			t.Errorf("%q: actual %d, passed %v, want %d, %v", tt.rule, got.Actual, got.Passed, tt.wantActual, tt.wantPassed)
+		}
+	}
+}
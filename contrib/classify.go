@@ -0,0 +1,108 @@
+package contrib
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultLabelKinds maps kinds to the labels commonly used for them.
+var DefaultLabelKinds = map[string][]string{
+	"fix":     {"bug", "kind/bug", "type/bug", "type: bug", "bugfix"},
+	"feature": {"enhancement", "feature", "kind/feature", "type/feature", "type: feature"},
+	"docs":    {"documentation", "docs", "kind/documentation", "kind/docs", "type/docs", "type: docs"},
+}
+
+// commitKinds maps conventional commit types to kinds.
+var commitKinds = map[string]string{
+	"fix":      "fix",
+	"feat":     "feature",
+	"docs":     "docs",
+	"refactor": "refactor",
+	"perf":     "perf",
+	"test":     "test",
+	"build":    "build",
+	"ci":       "ci",
+	"chore":    "chore",
+	"style":    "style",
+	"revert":   "revert",
+}
+
+var conventionalPrefix = regexp.MustCompile(`^([a-zA-Z]+)(\([^)]*\))?!?:`)
+
+// Classifier sets the kind of issues, pull requests and commits from their
+// labels, falling back to the conventional commit prefix of the title such
+// as "fix:" or "feat(api):".
+type Classifier struct {
+	// LabelKinds maps kinds to labels. Labels are matched case-insensitively.
+	LabelKinds map[string][]string
+}
+
+// Classify sets Kind of every contribution without one. Ledger entries keep
+// the kind they were declared with.
+func (c Classifier) Classify(contributions []Contribution) {
+	kinds := make(map[string]string)
+	for kind, labels := range c.LabelKinds {
+		for _, l := range labels {
+			kinds[strings.ToLower(l)] = kind
+		}
+	}
+
+	for i := range contributions {
+		if contributions[i].Kind != "" {
+			continue
+		}
+		contributions[i].Kind = c.kind(contributions[i], kinds)
+	}
+}
+
+func (c Classifier) kind(contribution Contribution, kinds map[string]string) string {
+	for _, l := range contribution.Labels {
+		if k, ok := kinds[strings.ToLower(l)]; ok {
+			return k
+		}
+	}
+	m := conventionalPrefix.FindStringSubmatch(contribution.Title)
+	if m == nil {
+		return ""
+	}
+	return commitKinds[strings.ToLower(m[1])]
+}
+
+// FilterLabels returns the issues, pull requests and reviews having any of
+// the include labels, or all if include is empty, and none of the exclude
+// labels. Commits and ledger entries have no labels and are kept. Labels are
+// compared case-insensitively.
+func FilterLabels(contributions []Contribution, include, exclude []string) []Contribution {
+	if len(include) == 0 && len(exclude) == 0 {
+		return contributions
+	}
+
+	var filtered []Contribution
+	for _, c := range contributions {
+		switch c.Type {
+		case Issue, PullRequest, Review:
+		default:
+			filtered = append(filtered, c)
+			continue
+		}
+		if len(include) > 0 && !hasLabel(c, include) {
+			continue
+		}
+		if hasLabel(c, exclude) {
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+	return filtered
+}
+
+func hasLabel(c Contribution, labels []string) bool {
+	for _, l := range c.Labels {
+		for _, want := range labels {
+			if strings.EqualFold(l, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,344 @@
+package contrib
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/muesli/termenv"
+)
+
+// Column is a column of the list and summary tables.
+type Column struct {
+	ID   string
+	Name string
+
+	// Width is the width reserved for the column when the widths of the
+	// columns with a WidthRatio are computed.
+	Width int
+	// WidthRatio limits the column to this share of the remaining width.
+	// The ratios of the visible columns are scaled down if they add up to
+	// more than 1.
+	WidthRatio float64
+	AlignLeft  bool
+
+	// item and summary return the raw value used for sorting. Columns
+	// without one are left blank in the respective table.
+	item    func(Contribution) interface{}
+	summary func(Summary) interface{}
+	// format renders the raw value, fmt.Sprint is used if nil.
+	format func(r TableRenderer, v interface{}) string
+}
+
+var columns = []Column{
+	{ID: "year", Name: "Year", Width: 7,
+		item:   func(c Contribution) interface{} { return c.Year() },
+		format: TableRenderer.yearTransformer},
+	{ID: "title", Name: "Title", WidthRatio: 0.7, AlignLeft: true,
+		item: func(c Contribution) interface{} { return c.Title }},
+	{ID: "repo", Name: "Repo", WidthRatio: 0.3, AlignLeft: true,
+		item: func(c Contribution) interface{} { return c.Repo }},
+	{ID: "pr", Name: "PR", Width: 3,
+		item: func(c Contribution) interface{} { return isPR(c.Type == PullRequest) }},
+	{ID: "kind", Name: "Kind", Width: 11,
+		item: func(c Contribution) interface{} { return kindString(c.Kind) }},
+	{ID: "number", Name: "Number", Width: 6,
+		item:   func(c Contribution) interface{} { return c.Number },
+		format: func(_ TableRenderer, v interface{}) string { return numberString(v.(int)) }},
+	{ID: "url", Name: "URL", WidthRatio: 0.5, AlignLeft: true,
+		item: func(c Contribution) interface{} { return c.URL }},
+
+	// Repo metadata, see Enrich. They are also the keys of the summaries
+	// grouped by them.
+	{ID: "stars", Name: "Stars", Width: 9,
+		item: func(c Contribution) interface{} {
+			if c.RepoInfo == nil || c.RepoInfo.Missing {
+				return -1
+			}
+			return c.RepoInfo.Stars
+		},
+		format: func(_ TableRenderer, v interface{}) string {
+			if n, ok := v.(int); ok && n < 0 {
+				return "-"
+			}
+			return fmt.Sprint(v)
+		}},
+	{ID: "topics", Name: "Topics", WidthRatio: 0.3, AlignLeft: true,
+		item: func(c Contribution) interface{} {
+			return repoInfoString(c, func(i RepoInfo) string { return strings.Join(i.Topics, ", ") })
+		}},
+	{ID: "license", Name: "License", Width: 12,
+		item: func(c Contribution) interface{} {
+			return repoInfoString(c, func(i RepoInfo) string { return i.License })
+		}},
+	{ID: "archived", Name: "Archived", Width: 8,
+		item: func(c Contribution) interface{} {
+			return repoInfoString(c, func(i RepoInfo) string { return yesNo(i.Archived) })
+		}},
+	{ID: "fork", Name: "Fork", Width: 4,
+		item: func(c Contribution) interface{} {
+			return repoInfoString(c, func(i RepoInfo) string { return yesNo(i.Fork) })
+		}},
+	{ID: "lines", Name: "Lines", Width: 7,
+		item: func(c Contribution) interface{} {
+			if c.LinesChanged == nil {
+				return -1
+			}
+			return *c.LinesChanged
+		},
+		format: func(_ TableRenderer, v interface{}) string {
+			if n, ok := v.(int); ok && n < 0 {
+				return "-"
+			}
+			return fmt.Sprint(v)
+		}},
+	// score is the score of an item, or the sum of the scores of a group.
+	{ID: "score", Name: "Score", Width: 7,
+		item:    func(c Contribution) interface{} { return c.Score },
+		summary: func(s Summary) interface{} { return s.Score },
+		format:  scoreString},
+	{ID: "foundation", Name: "Foundation", Width: 10, AlignLeft: true,
+		item: func(c Contribution) interface{} { return dashIfEmpty(c.Foundation) }},
+	{ID: "maturity", Name: "Maturity", Width: 10, AlignLeft: true,
+		item: func(c Contribution) interface{} { return dashIfEmpty(c.Maturity) }},
+	{ID: "activity", Name: "Last commit", Width: 11,
+		item: func(c Contribution) interface{} {
+			return repoInfoString(c, func(i RepoInfo) string {
+				if i.LastCommitAt == nil {
+					return ""
+				}
+				return i.LastCommitAt.Format("2006-01-02")
+			})
+		}},
+
+	// Repo/Year base summary
+	{ID: "issue_num", Name: "issue count", Width: 3,
+		summary: func(s Summary) interface{} { return s.Issues }},
+	{ID: "pr_num", Name: "PR count", Width: 3,
+		summary: func(s Summary) interface{} { return s.PRs }},
+	{ID: "commit_num", Name: "commit count", Width: 3,
+		summary: func(s Summary) interface{} { return s.Commits }},
+	{ID: "other_num", Name: "other count", Width: 3,
+		summary: func(s Summary) interface{} { return s.Others }},
+	{ID: "issue_percent", Name: "issue%", WidthRatio: 0.35, AlignLeft: true,
+		summary: func(s Summary) interface{} { return s.IssuePercent },
+		format:  TableRenderer.barTransformer},
+	{ID: "pr_percent", Name: "PR%", WidthRatio: 0.35, AlignLeft: true,
+		summary: func(s Summary) interface{} { return s.PRPercent },
+		format:  TableRenderer.barTransformer},
+	{ID: "issue_score_percent", Name: "issue score%", WidthRatio: 0.35, AlignLeft: true,
+		summary: func(s Summary) interface{} { return s.IssueScorePercent },
+		format:  TableRenderer.barTransformer},
+	{ID: "pr_score_percent", Name: "PR score%", WidthRatio: 0.35, AlignLeft: true,
+		summary: func(s Summary) interface{} { return s.PRScorePercent },
+		format:  TableRenderer.barTransformer},
+	{ID: "merge_median", Name: "merge p50", Width: 9,
+		summary: func(s Summary) interface{} { return latencyValue(s.TimeToMerge, s.TimeToMerge.Median) },
+		format:  durationString},
+	{ID: "merge_p90", Name: "merge p90", Width: 9,
+		summary: func(s Summary) interface{} { return latencyValue(s.TimeToMerge, s.TimeToMerge.P90) },
+		format:  durationString},
+	{ID: "close_median", Name: "close p50", Width: 9,
+		summary: func(s Summary) interface{} { return latencyValue(s.TimeToClose, s.TimeToClose.Median) },
+		format:  durationString},
+	{ID: "close_p90", Name: "close p90", Width: 9,
+		summary: func(s Summary) interface{} { return latencyValue(s.TimeToClose, s.TimeToClose.P90) },
+		format:  durationString},
+	{ID: "response_median", Name: "response p50", Width: 12,
+		summary: func(s Summary) interface{} { return latencyValue(s.TimeToFirstResponse, s.TimeToFirstResponse.Median) },
+		format:  durationString},
+	{ID: "response_p90", Name: "response p90", Width: 12,
+		summary: func(s Summary) interface{} { return latencyValue(s.TimeToFirstResponse, s.TimeToFirstResponse.P90) },
+		format:  durationString},
+}
+
+// Columns returns the columns of the list and summary tables.
+func Columns() []Column {
+	c := make([]Column, len(columns))
+	copy(c, columns)
+	return c
+}
+
+// ColumnIDs returns a slice of all column IDs.
+func ColumnIDs() []string {
+	s := make([]string, len(columns))
+	for i, v := range columns {
+		s[i] = v.ID
+	}
+
+	return s
+}
+
+// ParseColumns parses a comma separated list of column IDs.
+func ParseColumns(cols string) ([]string, error) {
+	var ids []string
+
+	s := strings.Split(cols, ",")
+	for _, v := range s {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if len(v) == 0 {
+			continue
+		}
+
+		if _, err := lookupColumn(v); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, v)
+	}
+
+	return ids, nil
+}
+
+// ParseStyle converts user-provided style option into a table.Style.
+func ParseStyle(styleOpt string) (table.Style, error) {
+	switch styleOpt {
+	case "unicode":
+		return table.StyleRounded, nil
+	case "ascii":
+		return table.StyleDefault, nil
+	default:
+		return table.Style{}, fmt.Errorf("Unknown style option: %s", styleOpt)
+	}
+}
+
+// lookupColumn returns the column with the given ID.
+func lookupColumn(id string) (Column, error) {
+	for _, v := range columns {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+
+	return Column{}, fmt.Errorf("unknown column: %s (valid: %s)", id, strings.Join(ColumnIDs(), ", "))
+}
+
+// yearTransformer colors the year.
+func (r TableRenderer) yearTransformer(val interface{}) string {
+	return termenv.String(val.(string)).Foreground(r.Theme.Blue).String()
+}
+
+// barTransformer transforms a percentage into a progress-bar.
+func (r TableRenderer) barTransformer(val interface{}) string {
+	usage := val.(float64)
+	s := termenv.String()
+	if usage >= 0 {
+		if r.barWidth() > 0 {
+			bw := r.barWidth() - 2
+			s = termenv.String(fmt.Sprintf("[%s%s] %5.1f%%",
+				strings.Repeat("#", int(usage*float64(bw))),
+				strings.Repeat(".", bw-int(usage*float64(bw))),
+				usage*100,
+			))
+		} else {
+			s = termenv.String(fmt.Sprintf("%5.1f%%", usage*100))
+		}
+	}
+
+	// apply color to progress-bar
+	switch {
+	case usage >= 0.9:
+		s = s.Foreground(r.Theme.Red)
+	case usage >= 0.5:
+		s = s.Foreground(r.Theme.Yellow)
+	default:
+		s = s.Foreground(r.Theme.Green)
+	}
+	return s.String()
+}
+
+// progressBar renders done of target as a bar, green once the target is
+// reached.
+func (r TableRenderer) progressBar(done, target int) string {
+	p := 1.0
+	if target > 0 {
+		p = float64(done) / float64(target)
+	}
+	full := p
+	if full > 1 {
+		full = 1
+	}
+	const bw = 20
+	s := termenv.String(fmt.Sprintf("[%s%s] %d/%d",
+		strings.Repeat("#", int(full*bw)),
+		strings.Repeat(".", bw-int(full*bw)),
+		done, target,
+	))
+	switch {
+	case p >= 1:
+		s = s.Foreground(r.Theme.Green)
+	case p >= 0.5:
+		s = s.Foreground(r.Theme.Yellow)
+	default:
+		s = s.Foreground(r.Theme.Red)
+	}
+	return s.String()
+}
+
+// barWidth returns the width of progress-bars for the given render width.
+func (r TableRenderer) barWidth() int {
+	var w int
+	switch {
+	case r.width() < 100:
+		return 0
+	case r.width() < 120:
+		w = 12
+	default:
+		w = 22
+	}
+	// the bar is followed by " 100.0%"
+	if r.columnWidth > 0 && r.columnWidth-7 < w {
+		w = r.columnWidth - 7
+	}
+	if w < 5 {
+		return 0
+	}
+	return w
+}
+
+// less compares two raw column values.
+func less(a, b interface{}) bool {
+	switch a := a.(type) {
+	case int:
+		return a < b.(int)
+	case float64:
+		return a < b.(float64)
+	case time.Time:
+		return a.Before(b.(time.Time))
+	case time.Duration:
+		return a < b.(time.Duration)
+	case bool:
+		return !a && b.(bool)
+	default:
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	}
+}
+
+func kindString(kind string) string {
+	if kind == "" {
+		return "-"
+	}
+	return kind
+}
+
+func numberString(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("#%d", n)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func isPR(b bool) string {
+	if b {
+		return "○"
+	}
+	return "-"
+}
@@ -0,0 +1,321 @@
+package contrib
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
+)
+
+// maxGrew is the number of repos listed as grown the most in a comparison.
+const maxGrew = 5
+
+// Period is a time window from the start of the day Since to the end of the
+// day Until in UTC.
+type Period struct {
+	Since time.Time
+	Until time.Time
+}
+
+// ParsePeriod parses the first and the last day of a period as YYYY-MM-DD.
+func ParsePeriod(since, until string) (Period, error) {
+	s, err := time.Parse("2006-01-02", since)
+	if err != nil {
+		return Period{}, fmt.Errorf("since must be YYYY-MM-DD: %s", since)
+	}
+	u, err := time.Parse("2006-01-02", until)
+	if err != nil {
+		return Period{}, fmt.Errorf("until must be YYYY-MM-DD: %s", until)
+	}
+	if u.Before(s) {
+		return Period{}, fmt.Errorf("until %s is before since %s", until, since)
+	}
+	return Period{Since: s, Until: u}, nil
+}
+
+func (p Period) String() string {
+	return p.Since.Format("2006-01-02") + ".." + p.Until.Format("2006-01-02")
+}
+
+// Filter returns the contributions created in the period.
+func (p Period) Filter(contributions []Contribution) []Contribution {
+	end := p.Until.AddDate(0, 0, 1)
+	var filtered []Contribution
+	for _, c := range contributions {
+		if !c.CreatedAt.Before(p.Since) && c.CreatedAt.Before(end) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// Units of Delta values other than counts.
+const (
+	UnitScore = "score"
+	UnitHours = "hours"
+)
+
+// Delta is a value in two data sets, e.g. two periods or two accounts.
+type Delta struct {
+	Key   string  `json:"key"`
+	A     float64 `json:"a"`
+	B     float64 `json:"b"`
+	Delta float64 `json:"delta"`
+	// Percent is the change relative to A, nil if A is 0.
+	Percent *float64 `json:"percent,omitempty"`
+	// Unit is UnitScore or UnitHours, "" for counts.
+	Unit string `json:"unit,omitempty"`
+}
+
+func newDelta(key string, a, b float64) Delta {
+	d := Delta{Key: key, A: a, B: b, Delta: b - a}
+	if a != 0 {
+		p := (b - a) / a
+		d.Percent = &p
+	}
+	return d
+}
+
+// RepoDelta compares the contributions to a repo. IssuePercent and PRPercent
+// are its shares of all issues and pull requests in A and B.
+type RepoDelta struct {
+	Delta
+	IssuePercent [2]float64 `json:"issue_percent"`
+	PRPercent    [2]float64 `json:"pr_percent"`
+}
+
+// Comparison compares the contributions of two periods or two accounts.
+type Comparison struct {
+	// Labels name the two data sets, e.g. the periods or the accounts.
+	Labels [2]string `json:"labels"`
+	// Totals compares the summary columns of all contributions and the
+	// number of repos. The shares of issues and pull requests are compared
+	// per repo, as the total is always all of them.
+	Totals []Delta `json:"totals"`
+	// Repos compares the contributions per repo, sorted by repo.
+	Repos []RepoDelta `json:"repos"`
+	// New and Dropped are the repos with contributions only in B and only in
+	// A, Grew the repos grown the most from A to B.
+	New     []string    `json:"new"`
+	Dropped []string    `json:"dropped"`
+	Grew    []RepoDelta `json:"grew"`
+}
+
+// Compare compares the contributions a and b.
+func Compare(labels [2]string, a, b []Contribution) Comparison {
+	c := Comparison{Labels: labels, Repos: []RepoDelta{}, New: []string{}, Dropped: []string{}, Grew: []RepoDelta{}}
+
+	total := func(Contribution) string { return "" }
+	var sa, sb Summary
+	if s := Summarize(a, total); len(s) > 0 {
+		sa = s[0]
+	}
+	if s := Summarize(b, total); len(s) > 0 {
+		sb = s[0]
+	}
+	ra, rb := Summarize(a, ByRepo), Summarize(b, ByRepo)
+	c.Totals = summaryDeltas(sa, sb)
+	c.Totals = append(c.Totals, newDelta("repos", float64(len(ra)), float64(len(rb))))
+
+	repos := make(map[string]*RepoDelta)
+	for i, summaries := range [][]Summary{ra, rb} {
+		for _, s := range summaries {
+			d, ok := repos[s.Key]
+			if !ok {
+				d = &RepoDelta{Delta: Delta{Key: s.Key}}
+				repos[s.Key] = d
+			}
+			n := float64(s.Issues + s.PRs + s.Commits + s.Others)
+			if i == 0 {
+				d.A = n
+			} else {
+				d.B = n
+			}
+			d.IssuePercent[i] = s.IssuePercent
+			d.PRPercent[i] = s.PRPercent
+		}
+	}
+	for repo, r := range repos {
+		d := *r
+		d.Delta = newDelta(repo, r.A, r.B)
+		c.Repos = append(c.Repos, d)
+		switch {
+		case d.A == 0:
+			c.New = append(c.New, repo)
+		case d.B == 0:
+			c.Dropped = append(c.Dropped, repo)
+		case d.Delta.Delta > 0:
+			c.Grew = append(c.Grew, d)
+		}
+	}
+	sort.Slice(c.Repos, func(i, j int) bool { return c.Repos[i].Key < c.Repos[j].Key })
+	sort.Strings(c.New)
+	sort.Strings(c.Dropped)
+	sort.Slice(c.Grew, func(i, j int) bool {
+		if c.Grew[i].Delta.Delta != c.Grew[j].Delta.Delta {
+			return c.Grew[i].Delta.Delta > c.Grew[j].Delta.Delta
+		}
+		return c.Grew[i].Key < c.Grew[j].Key
+	})
+	if len(c.Grew) > maxGrew {
+		c.Grew = c.Grew[:maxGrew]
+	}
+	return c
+}
+
+// summaryDeltas compares the columns of the summary table: the counts, the
+// score if any and the medians which are known in both.
+func summaryDeltas(a, b Summary) []Delta {
+	deltas := []Delta{
+		newDelta("issue count", float64(a.Issues), float64(b.Issues)),
+		newDelta("PR count", float64(a.PRs), float64(b.PRs)),
+		newDelta("commit count", float64(a.Commits), float64(b.Commits)),
+		newDelta("other count", float64(a.Others), float64(b.Others)),
+	}
+	if a.Score != 0 || b.Score != 0 {
+		d := newDelta("score", a.Score, b.Score)
+		d.Unit = UnitScore
+		deltas = append(deltas, d)
+	}
+	for _, m := range []struct {
+		key  string
+		a, b Latency
+	}{
+		{"merge p50", a.TimeToMerge, b.TimeToMerge},
+		{"close p50", a.TimeToClose, b.TimeToClose},
+		{"response p50", a.TimeToFirstResponse, b.TimeToFirstResponse},
+	} {
+		if m.a.Count == 0 || m.b.Count == 0 {
+			continue
+		}
+		d := newDelta(m.key, m.a.Median.Hours(), m.b.Median.Hours())
+		d.Unit = UnitHours
+		deltas = append(deltas, d)
+	}
+	return deltas
+}
+
+// RenderComparison writes the totals and the changed repos side by side, as
+// Markdown tables if markdown is set.
+func (r TableRenderer) RenderComparison(w io.Writer, c Comparison, markdown bool) error {
+	totals := r.comparisonTable(c.Labels, "Metric")
+	for _, d := range c.Totals {
+		totals.AppendRow(r.deltaRow(d, "", markdown))
+	}
+
+	repos := r.comparisonTable(c.Labels, "Repo", "Change", "issue%", "PR%")
+	byRepo := make(map[string]RepoDelta)
+	for _, d := range c.Repos {
+		byRepo[d.Key] = d
+	}
+	for _, repo := range c.New {
+		repos.AppendRow(r.repoDeltaRow(byRepo[repo], "new", markdown))
+	}
+	for _, repo := range c.Dropped {
+		repos.AppendRow(r.repoDeltaRow(byRepo[repo], "dropped", markdown))
+	}
+	for _, d := range c.Grew {
+		repos.AppendRow(r.repoDeltaRow(d, "grew", markdown))
+	}
+
+	for _, t := range []struct {
+		title string
+		tab   table.Writer
+		rows  int
+	}{
+		{fmt.Sprintf("%s vs %s", c.Labels[0], c.Labels[1]), totals, len(c.Totals)},
+		{"New, dropped and most grown repos", repos, len(c.New) + len(c.Dropped) + len(c.Grew)},
+	} {
+		if t.rows == 0 {
+			continue
+		}
+		var out string
+		if markdown {
+			out = fmt.Sprintf("### %s\n\n%s\n", t.title, t.tab.RenderMarkdown())
+		} else {
+			t.tab.SetTitle(t.title)
+			out = t.tab.Render()
+		}
+		if _, err := fmt.Fprintln(w, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r TableRenderer) comparisonTable(labels [2]string, key string, extra ...interface{}) table.Writer {
+	tab := table.NewWriter()
+	tab.SetAllowedRowLength(r.width())
+	tab.Style().Options.SeparateColumns = true
+	tab.SetStyle(r.Style)
+	// periods are wrapped after ".."
+	tab.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 2, Align: text.AlignRight, WidthMax: 12},
+		{Number: 3, Align: text.AlignRight, WidthMax: 12},
+		{Number: 4, Align: text.AlignRight},
+		{Number: 5, Align: text.AlignRight},
+	})
+	tab.AppendHeader(append(table.Row{key, labels[0], labels[1], "Δ", "Δ%"}, extra...))
+	return tab
+}
+
+// deltaRow returns the row of d, with the change appended if not empty.
+// Changes are colored unless markdown is set.
+func (r TableRenderer) deltaRow(d Delta, change string, markdown bool) table.Row {
+	delta := deltaValue(d.Delta, d.Unit)
+	if d.Delta > 0 {
+		delta = "+" + delta
+	}
+	if d.Delta < 0 && d.Unit == UnitHours {
+		delta = "-" + deltaValue(-d.Delta, d.Unit)
+	}
+	percent := "-"
+	if d.Percent != nil {
+		percent = fmt.Sprintf("%+.1f%%", *d.Percent*100)
+	}
+	if !markdown && d.Delta != 0 {
+		color := r.Theme.Green
+		if d.Delta < 0 {
+			color = r.Theme.Red
+		}
+		delta = termenv.String(delta).Foreground(color).String()
+		percent = termenv.String(percent).Foreground(color).String()
+	}
+	row := table.Row{d.Key, deltaValue(d.A, d.Unit), deltaValue(d.B, d.Unit), delta, percent}
+	if change != "" {
+		row = append(row, change)
+	}
+	return row
+}
+
+// repoDeltaRow returns the row of d with the change and the shares of
+// issues and pull requests.
+func (r TableRenderer) repoDeltaRow(d RepoDelta, change string, markdown bool) table.Row {
+	return append(r.deltaRow(d.Delta, change, markdown), shareChange(d.IssuePercent), shareChange(d.PRPercent))
+}
+
+// shareChange renders an unchanged share as is, and a changed one as
+// "previous → next".
+func shareChange(p [2]float64) string {
+	if p[0] == p[1] {
+		return fmt.Sprintf("%.1f%%", p[1]*100)
+	}
+	return fmt.Sprintf("%.1f%% → %.1f%%", p[0]*100, p[1]*100)
+}
+
+// deltaValue formats a value of unit.
+func deltaValue(v float64, unit string) string {
+	switch unit {
+	case UnitScore:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	case UnitHours:
+		return durationString(TableRenderer{}, time.Duration(v*float64(time.Hour)))
+	default:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+}
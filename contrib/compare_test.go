@@ -0,0 +1,178 @@
+package contrib
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func TestNewDelta(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        float64
+		wantDelta   float64
+		wantPercent *float64
+	}{
+		{name: "grew", a: 2, b: 3, wantDelta: 1, wantPercent: floatPtr(0.5)},
+		{name: "shrank", a: 4, b: 1, wantDelta: -3, wantPercent: floatPtr(-0.75)},
+		{name: "unchanged", a: 2, b: 2, wantDelta: 0, wantPercent: floatPtr(0)},
+		{name: "from zero", a: 0, b: 3, wantDelta: 3},
+		{name: "zero in both", a: 0, b: 0, wantDelta: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDelta("k", tt.a, tt.b)
+			if d.Delta != tt.wantDelta {
+				t.Errorf("Delta = %v, want %v", d.Delta, tt.wantDelta)
+			}
+			switch {
+			case tt.wantPercent == nil && d.Percent != nil:
+				t.Errorf("Percent = %v, want nil", *d.Percent)
+			case tt.wantPercent != nil && (d.Percent == nil || *d.Percent != *tt.wantPercent):
+				t.Errorf("Percent = %v, want %v", d.Percent, *tt.wantPercent)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	after := func(d time.Duration) *time.Time {
+		t := at.Add(d)
+		return &t
+	}
+	issue := func(repo string) Contribution {
+		return Contribution{Type: Issue, Repo: repo, CreatedAt: at}
+	}
+	merged := func(repo string, d time.Duration) Contribution {
+		return Contribution{Type: PullRequest, Repo: repo, CreatedAt: at, Closed: true, Merged: true, ClosedAt: after(d), MergedAt: after(d)}
+	}
+	scored := func(c Contribution, score float64) Contribution {
+		c.Score = score
+		return c
+	}
+	repeat := func(c Contribution, n int) []Contribution {
+		var s []Contribution
+		for i := 0; i < n; i++ {
+			s = append(s, c)
+		}
+		return s
+	}
+	concat := func(s ...[]Contribution) []Contribution {
+		var all []Contribution
+		for _, c := range s {
+			all = append(all, c...)
+		}
+		return all
+	}
+
+	tests := []struct {
+		name        string
+		a, b        []Contribution
+		wantTotals  map[string][2]float64
+		wantNew     []string
+		wantDropped []string
+		wantGrew    []string
+	}{
+		{
+			name: "counts and repos",
+			a:    []Contribution{issue("a/a"), issue("b/b")},
+			b:    []Contribution{issue("a/a"), issue("a/a"), issue("c/c")},
+			wantTotals: map[string][2]float64{
+				"issue count": {2, 3},
+				"PR count":    {0, 0},
+				"repos":       {2, 2},
+			},
+			wantNew:     []string{"c/c"},
+			wantDropped: []string{"b/b"},
+			wantGrew:    []string{"a/a"},
+		},
+		{
+			name: "score and medians known in both",
+			a:    []Contribution{scored(merged("a/a", 2*time.Hour), 1)},
+			b:    []Contribution{scored(merged("a/a", 4*time.Hour), 1.5), scored(merged("a/a", 4*time.Hour), 1.5)},
+			wantTotals: map[string][2]float64{
+				"PR count":  {1, 2},
+				"score":     {1, 3},
+				"merge p50": {2, 4},
+			},
+			wantGrew: []string{"a/a"},
+		},
+		{
+			name: "grew sorted by delta then repo and cut",
+			a:    []Contribution{issue("a/a"), issue("b/b"), issue("c/c"), issue("d/d"), issue("e/e"), issue("f/f")},
+			b: concat(
+				repeat(issue("a/a"), 2),
+				repeat(issue("b/b"), 4),
+				repeat(issue("c/c"), 2),
+				repeat(issue("d/d"), 3),
+				repeat(issue("e/e"), 2),
+				repeat(issue("f/f"), 2),
+			),
+			wantTotals: map[string][2]float64{"repos": {6, 6}},
+			wantGrew:   []string{"b/b", "d/d", "a/a", "c/c", "e/e"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Compare([2]string{"A", "B"}, tt.a, tt.b)
+			totals := make(map[string]Delta)
+			for _, d := range c.Totals {
+				totals[d.Key] = d
+			}
+			for key, want := range tt.wantTotals {
+				d, ok := totals[key]
+				if !ok {
+					t.Errorf("no %s in totals %+v", key, c.Totals)
+					continue
+				}
+				if d.A != want[0] || d.B != want[1] {
+					t.Errorf("%s = %v, %v, want %v, %v", key, d.A, d.B, want[0], want[1])
+				}
+			}
+			if _, ok := totals["close p50"]; ok {
+				t.Errorf("totals %+v have a close median without closed PRs", c.Totals)
+			}
+			if got := strings.Join(c.New, ","); got != strings.Join(tt.wantNew, ",") {
+				t.Errorf("New = %v, want %v", c.New, tt.wantNew)
+			}
+			if got := strings.Join(c.Dropped, ","); got != strings.Join(tt.wantDropped, ",") {
+				t.Errorf("Dropped = %v, want %v", c.Dropped, tt.wantDropped)
+			}
+			var grew []string
+			for _, d := range c.Grew {
+				grew = append(grew, d.Key)
+			}
+			if strings.Join(grew, ",") != strings.Join(tt.wantGrew, ",") {
+				t.Errorf("Grew = %v, want %v", grew, tt.wantGrew)
+			}
+		})
+	}
+}
+
+func TestCompareShares(t *testing.T) {
+	a := []Contribution{{Type: Issue, Repo: "a/a"}, {Type: Issue, Repo: "b/b"}}
+	b := []Contribution{{Type: Issue, Repo: "a/a"}, {Type: Issue, Repo: "a/a"}, {Type: Issue, Repo: "a/a"}, {Type: Issue, Repo: "b/b"}}
+	c := Compare([2]string{"A", "B"}, a, b)
+	if len(c.Repos) != 2 || c.Repos[0].Key != "a/a" {
+		t.Fatalf("Repos = %+v, want a/a and b/b", c.Repos)
+	}
+	if got := c.Repos[0].IssuePercent; got != [2]float64{0.5, 0.75} {
+		t.Errorf("a/a IssuePercent = %v, want [0.5 0.75]", got)
+	}
+
+	var out bytes.Buffer
+	if err := (TableRenderer{Style: table.StyleLight}).RenderComparison(&out, c, true); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "50.0% → 75.0%") {
+		t.Errorf("RenderComparison() = %s, want the issue share of a/a", out.String())
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,124 @@
+// Package contrib fetches, aggregates and renders OSS contributions.
+//
+// Contributions are read by a Fetcher (GitHub search, local git clones or a
+// ledger file), aggregated with Summarize and written with a TableRenderer,
+// RenderCSV or a Report. The package keeps no global state, so it can be used
+// from several goroutines at once.
+package contrib
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// Type is the type of a contribution.
+type Type string
+
+const (
+	Issue       Type = "issue"
+	PullRequest Type = "pr"
+	Commit      Type = "commit"
+	Ledger      Type = "ledger"
+
+	// Review is a pull request of someone else reviewed by the account.
+	Review Type = "review"
+)
+
+// Contribution is a single issue, pull request, review, commit or ledger
+// entry.
+type Contribution struct {
+	Type      Type      `json:"type"`
+	Number    int       `json:"number,omitempty"`
+	Title     string    `json:"title"`
+	Repo      string    `json:"repo"`
+	CreatedAt time.Time `json:"created_at"`
+	Closed    bool      `json:"closed"`
+	Merged    bool      `json:"merged,omitempty"`
+	Kind      string    `json:"kind,omitempty"`
+	Labels    []string  `json:"labels,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	// Private is set for issues and pull requests of private repos.
+	Private bool `json:"private,omitempty"`
+
+	// ClosedAt is set for closed issues and pull requests, MergedAt only
+	// for merged pull requests. It equals ClosedAt, as merging closes a
+	// pull request.
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+	MergedAt *time.Time `json:"merged_at,omitempty"`
+	// FirstResponseAt is the time of the first comment or review by a
+	// maintainer, see GitHubFetcher.ResponseTimes.
+	FirstResponseAt *time.Time `json:"first_response_at,omitempty"`
+	// ReviewedAt is the time of the first review by the account of a
+	// Review, see GitHubFetcher.Reviews.
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+
+	// RepoInfo is the metadata of the repo, see Enrich.
+	RepoInfo *RepoInfo `json:"repo_info,omitempty"`
+	// LinesChanged is the number of added and deleted lines of pull requests
+	// (see GitHubFetcher.Sizes) and local commits, nil if not known.
+	LinesChanged *int `json:"lines_changed,omitempty"`
+	// Score is set by ScoreWeights.Assign.
+	Score float64 `json:"score,omitempty"`
+
+	// Foundation and Maturity are set by Catalog.Assign.
+	Foundation string `json:"foundation,omitempty"`
+	Maturity   string `json:"maturity,omitempty"`
+}
+
+// Year returns the year the contribution was created in.
+func (c Contribution) Year() string {
+	return strconv.Itoa(c.CreatedAt.Year())
+}
+
+// State returns "open", "closed" or "merged" for issues, pull requests and
+// reviews, and "" for the other types.
+func (c Contribution) State() string {
+	switch c.Type {
+	case Issue, PullRequest, Review:
+	default:
+		return ""
+	}
+	switch {
+	case c.Merged:
+		return "merged"
+	case c.Closed:
+		return "closed"
+	default:
+		return "open"
+	}
+}
+
+// eventTime returns when the contribution reached state, which goals and
+// rules count it by: the first review of a review, the merge or close time
+// for "merged" or "closed", and the creation time otherwise or if unknown.
+func (c Contribution) eventTime(state string) time.Time {
+	switch {
+	case c.Type == Review && c.ReviewedAt != nil:
+		return *c.ReviewedAt
+	case state == "merged" && c.MergedAt != nil:
+		return *c.MergedAt
+	case state == "closed" && c.ClosedAt != nil:
+		return *c.ClosedAt
+	default:
+		return c.CreatedAt
+	}
+}
+
+// Fetcher reads contributions from one source.
+type Fetcher interface {
+	Fetch(ctx context.Context) ([]Contribution, error)
+}
+
+// FetchAll calls every fetcher in order and concatenates the results.
+func FetchAll(ctx context.Context, fetchers ...Fetcher) ([]Contribution, error) {
+	var contributions []Contribution
+	for _, f := range fetchers {
+		c, err := f.Fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		contributions = append(contributions, c...)
+	}
+	return contributions, nil
+}
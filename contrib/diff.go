@@ -0,0 +1,205 @@
+package contrib
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// Changes of an item between two reports.
+const (
+	ChangeOpened   = "opened"
+	ChangeAdded    = "added"
+	ChangeMerged   = "merged"
+	ChangeClosed   = "closed"
+	ChangeReopened = "reopened"
+	ChangeRetitled = "retitled"
+)
+
+// ItemChange is a change of an item between two reports.
+type ItemChange struct {
+	Change string `json:"change"`
+	Contribution
+	// OldTitle is the title in the old report of a retitled item.
+	OldTitle string `json:"old_title,omitempty"`
+}
+
+// SummaryChange is a summary row whose counts changed between two reports.
+type SummaryChange struct {
+	Group string  `json:"group"`
+	Old   Summary `json:"old"`
+	New   Summary `json:"new"`
+}
+
+// ReportDiff is what happened between two reports.
+type ReportDiff struct {
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+	Items     []ItemChange    `json:"items"`
+	Summaries []SummaryChange `json:"summaries"`
+}
+
+// DiffReports returns the items which were opened, merged, closed without
+// merging, reopened or retitled between the previous and the next report, and
+// the year and repo summary rows whose counts changed. Items only in the next
+// report are opened if they are issues or pull requests, added otherwise,
+// and merged or closed too if they already are.
+func DiffReports(prev, next Report) ReportDiff {
+	d := ReportDiff{From: prev.FetchedAt, To: next.FetchedAt, Items: []ItemChange{}, Summaries: []SummaryChange{}}
+
+	prevs := make(map[string]Contribution, len(prev.Items))
+	for _, c := range prev.Items {
+		prevs[itemKey(c)] = c
+	}
+	for _, c := range next.Items {
+		o, ok := prevs[itemKey(c)]
+		if !ok {
+			switch c.Type {
+			case Issue, PullRequest:
+				d.Items = append(d.Items, ItemChange{Change: ChangeOpened, Contribution: c})
+				o = Contribution{Type: c.Type}
+			default:
+				d.Items = append(d.Items, ItemChange{Change: ChangeAdded, Contribution: c})
+				continue
+			}
+		}
+		if ok && o.Title != c.Title {
+			d.Items = append(d.Items, ItemChange{Change: ChangeRetitled, Contribution: c, OldTitle: o.Title})
+		}
+		switch was, is := o.State(), c.State(); {
+		case was == is:
+		case is == "merged":
+			d.Items = append(d.Items, ItemChange{Change: ChangeMerged, Contribution: c})
+		case is == "closed":
+			d.Items = append(d.Items, ItemChange{Change: ChangeClosed, Contribution: c})
+		case is == "open":
+			d.Items = append(d.Items, ItemChange{Change: ChangeReopened, Contribution: c})
+		}
+	}
+
+	for _, group := range []string{"year", "repo"} {
+		before := make(map[string]Summary)
+		for _, s := range Summarize(prev.Items, Groups[group]) {
+			before[s.Key] = s
+		}
+		after := make(map[string]Summary)
+		for _, s := range Summarize(next.Items, Groups[group]) {
+			after[s.Key] = s
+		}
+		var keys []string
+		for k := range before {
+			keys = append(keys, k)
+		}
+		for k := range after {
+			if _, ok := before[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			o, n := before[k], after[k]
+			if o.Issues == n.Issues && o.PRs == n.PRs && o.Commits == n.Commits && o.Others == n.Others {
+				continue
+			}
+			o.Key, n.Key = k, k
+			d.Summaries = append(d.Summaries, SummaryChange{Group: group, Old: o, New: n})
+		}
+	}
+	return d
+}
+
+// itemKey identifies an item across reports.
+func itemKey(c Contribution) string {
+	if c.URL != "" {
+		return string(c.Type) + " " + c.URL
+	}
+	if c.Number != 0 {
+		return fmt.Sprintf("%s %s#%d", c.Type, c.Repo, c.Number)
+	}
+	return fmt.Sprintf("%s %s %s %s", c.Type, c.Repo, c.CreatedAt.Format(time.RFC3339), c.Title)
+}
+
+// RenderDiff writes the changed items and summary rows, as Markdown tables if
+// markdown is set.
+func (r TableRenderer) RenderDiff(w io.Writer, d ReportDiff, markdown bool) error {
+	items := table.NewWriter()
+	items.SetAllowedRowLength(r.width())
+	items.Style().Options.SeparateColumns = true
+	items.SetStyle(r.Style)
+	// the rest after the change and the item columns, paddings and separators
+	rest := r.width() - 8 - 30 - 3*3 - 1
+	if rest < 10 {
+		rest = 10
+	}
+	items.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 2, WidthMax: 30},
+		{Number: 3, WidthMax: rest, WidthMaxEnforcer: text.WrapSoft},
+	})
+	items.AppendHeader(table.Row{"Change", "Item", "Title"})
+	for _, c := range d.Items {
+		title := c.Title
+		if c.Change == ChangeRetitled {
+			title = fmt.Sprintf("%s (was: %s)", c.Title, c.OldTitle)
+		}
+		items.AppendRow(table.Row{c.Change, c.Repo + numberString(c.Number), title})
+	}
+
+	summaries := table.NewWriter()
+	summaries.SetAllowedRowLength(r.width())
+	summaries.Style().Options.SeparateColumns = true
+	summaries.SetStyle(r.Style)
+	summaries.AppendHeader(table.Row{"Group", "Key", "issue count", "PR count", "commit count", "other count"})
+	for _, s := range d.Summaries {
+		summaries.AppendRow(table.Row{
+			s.Group,
+			s.New.Key,
+			countChange(s.Old.Issues, s.New.Issues),
+			countChange(s.Old.PRs, s.New.PRs),
+			countChange(s.Old.Commits, s.New.Commits),
+			countChange(s.Old.Others, s.New.Others),
+		})
+	}
+
+	period := fmt.Sprintf("%s to %s", d.From.Format("2006-01-02 15:04"), d.To.Format("2006-01-02 15:04"))
+	for _, t := range []struct {
+		title string
+		tab   table.Writer
+		rows  int
+	}{
+		{"Changed items, " + period, items, len(d.Items)},
+		{"Changed summaries", summaries, len(d.Summaries)},
+	} {
+		if t.rows == 0 {
+			continue
+		}
+		var out string
+		if markdown {
+			out = fmt.Sprintf("### %s\n\n%s\n", t.title, t.tab.RenderMarkdown())
+		} else {
+			t.tab.SetTitle(t.title)
+			out = t.tab.Render()
+		}
+		if _, err := fmt.Fprintln(w, out); err != nil {
+			return err
+		}
+	}
+	if len(d.Items) == 0 && len(d.Summaries) == 0 {
+		_, err := fmt.Fprintf(w, "No changes, %s\n", period)
+		return err
+	}
+	return nil
+}
+
+// countChange renders an unchanged count as is, and a changed one as
+// "previous → next".
+func countChange(prev, next int) string {
+	if prev == next {
+		return strconv.Itoa(next)
+	}
+	return fmt.Sprintf("%d → %d", prev, next)
+}
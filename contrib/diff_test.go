@@ -0,0 +1,104 @@
+package contrib
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func TestDiffReports(t *testing.T) {
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	pr := func(n int, title string, closed, merged bool) Contribution {
+		return Contribution{
+			Type:      PullRequest,
+			Number:    n,
+			Title:     title,
+			Repo:      "a/a",
+			URL:       fmt.Sprintf("https://github.com/a/a/pull/%d", n),
+			CreatedAt: at,
+			Closed:    closed,
+			Merged:    merged,
+		}
+	}
+	ledger := Contribution{Type: Ledger, Repo: "a/a", Title: "talk", CreatedAt: at}
+
+	tests := []struct {
+		name        string
+		prev, next  []Contribution
+		wantChanges []string
+		wantRows    int
+	}{
+		{
+			name:     "nothing changed",
+			prev:     []Contribution{pr(1, "x", false, false)},
+			next:     []Contribution{pr(1, "x", false, false)},
+			wantRows: 0,
+		},
+		{
+			name:        "merged, closed and reopened",
+			prev:        []Contribution{pr(1, "x", false, false), pr(2, "y", false, false), pr(3, "z", true, false)},
+			next:        []Contribution{pr(1, "x", true, true), pr(2, "y", true, false), pr(3, "z", false, false)},
+			wantChanges: []string{ChangeMerged, ChangeClosed, ChangeReopened},
+			wantRows:    0,
+		},
+		{
+			name:        "retitled",
+			prev:        []Contribution{pr(1, "x", false, false)},
+			next:        []Contribution{pr(1, "x2", false, false)},
+			wantChanges: []string{ChangeRetitled},
+		},
+		{
+			name:        "opened already merged and added",
+			next:        []Contribution{pr(1, "x", true, true), ledger},
+			wantChanges: []string{ChangeOpened, ChangeMerged, ChangeAdded},
+			// the year and the repo row
+			wantRows: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DiffReports(Report{Items: tt.prev}, Report{Items: tt.next})
+			var changes []string
+			for _, c := range d.Items {
+				changes = append(changes, c.Change)
+			}
+			if len(changes) != len(tt.wantChanges) {
+				t.Fatalf("changes = %v, want %v", changes, tt.wantChanges)
+			}
+			for i := range changes {
+				if changes[i] != tt.wantChanges[i] {
+					t.Fatalf("changes = %v, want %v", changes, tt.wantChanges)
+				}
+			}
+			if len(d.Summaries) != tt.wantRows {
+				t.Errorf("%d summary rows changed, want %d: %+v", len(d.Summaries), tt.wantRows, d.Summaries)
+			}
+		})
+	}
+}
+
+func TestDiffReportsRetitledOldTitle(t *testing.T) {
+	prev := Contribution{Type: Issue, Number: 1, Repo: "a/a", Title: "old"}
+	next := prev
+	next.Title = "new"
+	d := DiffReports(Report{Items: []Contribution{prev}}, Report{Items: []Contribution{next}})
+	if len(d.Items) != 1 || d.Items[0].OldTitle != "old" || d.Items[0].Title != "new" {
+		t.Errorf("DiffReports() items = %+v, want one retitled from old to new", d.Items)
+	}
+}
+
+func TestRenderDiffNarrow(t *testing.T) {
+	d := ReportDiff{Items: []ItemChange{{Change: ChangeMerged, Contribution: Contribution{Type: PullRequest, Repo: "a/a", Number: 1, Title: "Fix the crash on start"}}}}
+	var b bytes.Buffer
+	// the title column keeps a minimum width
+	if err := (TableRenderer{Style: table.StyleLight, Width: 30}).RenderDiff(&b, d, false); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "Fix the crash") || !strings.Contains(b.String(), "crash on") {
+		t.Errorf("RenderDiff() = %s, want the title wrapped at 10", b.String())
+	}
+}
@@ -0,0 +1,287 @@
+package contrib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
+	"gopkg.in/yaml.v2"
+)
+
+// EventStates are the PR states accepted in the states of event rules.
+var EventStates = []string{"open", "closed", "merged", "approved"}
+
+// EventRules are the rules of an event such as Hacktoberfest deciding which
+// pull requests count.
+type EventRules struct {
+	Name string `yaml:"name"`
+	// Start and End are the first and the last day of the event,
+	// YYYY-MM-DD in UTC.
+	Start  string `yaml:"start"`
+	End    string `yaml:"end"`
+	Target int    `yaml:"target"`
+
+	// Topics are the repository topics of participating repositories. A pull
+	// request with an accepted label participates regardless of the topics.
+	Topics         []string `yaml:"topics"`
+	AcceptedLabels []string `yaml:"accepted_labels"`
+	// States are the states a pull request needs to be in, any state if
+	// empty. An accepted label counts as accepted state too.
+	States        []string `yaml:"states"`
+	InvalidLabels []string `yaml:"invalid_labels"`
+}
+
+// EventInfo is the data needed to evaluate event rules which is not part of
+// a contribution.
+type EventInfo struct {
+	// Topics maps "owner/repo" to the topics of the repository.
+	Topics map[string][]string
+	// Approved is the set of the URLs of approved pull requests.
+	Approved map[string]bool
+}
+
+// EventPR is a pull request evaluated against event rules.
+type EventPR struct {
+	Contribution
+	Eligible bool     `json:"eligible"`
+	Reasons  []string `json:"reasons"`
+}
+
+// EventResult is the evaluation of all pull requests of an account.
+type EventResult struct {
+	Rules    EventRules `json:"rules"`
+	PRs      []EventPR  `json:"prs"`
+	Eligible int        `json:"eligible"`
+}
+
+// LoadEventRules parses a YAML rules file and validates it.
+func LoadEventRules(path string) (EventRules, error) {
+	var rules EventRules
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return rules, err
+	}
+	if err := yaml.UnmarshalStrict(b, &rules); err != nil {
+		return rules, fmt.Errorf("failed to parse event rules %s: %w", path, err)
+	}
+	if err := rules.validate(); err != nil {
+		return rules, fmt.Errorf("invalid event rules %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+func (r EventRules) validate() error {
+	var problems []string
+	start, err := time.Parse("2006-01-02", r.Start)
+	if err != nil {
+		problems = append(problems, "start must be YYYY-MM-DD: "+r.Start)
+	}
+	end, err := time.Parse("2006-01-02", r.End)
+	if err != nil {
+		problems = append(problems, "end must be YYYY-MM-DD: "+r.End)
+	}
+	if end.Before(start) {
+		problems = append(problems, "end is before start")
+	}
+	if r.Target < 0 {
+		problems = append(problems, "target must not be negative")
+	}
+	for _, s := range r.States {
+		if !contains(EventStates, s) {
+			problems = append(problems, fmt.Sprintf("unknown state %q (valid: %s)", s, strings.Join(EventStates, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// window returns the start of the first and the end of the last day.
+func (r EventRules) window() (time.Time, time.Time) {
+	// the dates have been validated already
+	start, _ := time.Parse("2006-01-02", r.Start)
+	end, _ := time.Parse("2006-01-02", r.End)
+	return start, end.AddDate(0, 0, 1)
+}
+
+// FetchEvent fetches the pull requests the account opened during the event,
+// and the topics of their repositories and their reviews needed by the rules.
+func (c *Client) FetchEvent(ctx context.Context, account string, rules EventRules) ([]Contribution, EventInfo, error) {
+	info := EventInfo{Topics: make(map[string][]string), Approved: make(map[string]bool)}
+	query := fmt.Sprintf("author:%s is:pr created:%s..%s", account, rules.Start, rules.End)
+	prs, err := c.SearchIssues(ctx, query)
+	if err != nil {
+		return nil, info, err
+	}
+
+	if contains(rules.States, "approved") {
+		approved, err := c.searchIssues(ctx, query+" review:approved")
+		if err != nil {
+			return nil, info, err
+		}
+		for _, i := range approved {
+			info.Approved[i.GetHTMLURL()] = true
+		}
+	}
+
+	if len(rules.Topics) > 0 {
+		for _, pr := range prs {
+			if _, ok := info.Topics[pr.Repo]; ok {
+				continue
+			}
+			s := strings.SplitN(pr.Repo, "/", 2)
+			if len(s) != 2 {
+				continue
+			}
+			var topics []string
+			err := retryRateLimit(ctx, func() (err error) {
+				topics, _, err = c.gc.Repositories.ListAllTopics(ctx, s[0], s[1])
+				return err
+			})
+			if err != nil {
+				return nil, info, fmt.Errorf("failed to get the topics of %s: %w", pr.Repo, err)
+			}
+			info.Topics[pr.Repo] = topics
+		}
+	}
+	return prs, info, nil
+}
+
+// Evaluate decides for every pull request whether it counts for the event.
+func (r EventRules) Evaluate(contributions []Contribution, info EventInfo) EventResult {
+	result := EventResult{Rules: r, PRs: []EventPR{}}
+	start, end := r.window()
+	for _, c := range contributions {
+		if c.Type != PullRequest {
+			continue
+		}
+		pr := EventPR{Contribution: c}
+		var failed, passed []string
+
+		if c.CreatedAt.Before(start) || !c.CreatedAt.Before(end) {
+			failed = append(failed, "opened outside of the event")
+		}
+		if l := matchingLabels(c, r.InvalidLabels); len(l) > 0 {
+			failed = append(failed, "labelled "+strings.Join(l, ", "))
+		}
+
+		accepted := matchingLabels(c, r.AcceptedLabels)
+		if len(accepted) > 0 {
+			passed = append(passed, "labelled "+strings.Join(accepted, ", "))
+		}
+		if len(r.Topics) > 0 && len(accepted) == 0 {
+			if t := intersect(info.Topics[c.Repo], r.Topics); len(t) > 0 {
+				passed = append(passed, "repo topic "+strings.Join(t, ", "))
+			} else {
+				failed = append(failed, "repo has none of the topics "+strings.Join(r.Topics, ", "))
+			}
+		}
+		if len(r.States) > 0 && len(accepted) == 0 {
+			state := c.State()
+			switch {
+			case contains(r.States, state):
+				passed = append(passed, state)
+			case contains(r.States, "approved") && info.Approved[c.URL]:
+				passed = append(passed, "approved")
+			default:
+				failed = append(failed, fmt.Sprintf("%s, not %s", state, strings.Join(r.States, " or ")))
+			}
+		}
+
+		pr.Eligible = len(failed) == 0
+		pr.Reasons = passed
+		if !pr.Eligible {
+			pr.Reasons = failed
+		}
+		if pr.Eligible {
+			result.Eligible++
+		}
+		result.PRs = append(result.PRs, pr)
+	}
+	return result
+}
+
+// RenderEvent writes the eligibility of every pull request and the progress
+// toward the target.
+func (r TableRenderer) RenderEvent(w io.Writer, result EventResult) error {
+	tab := table.NewWriter()
+	tab.SetAllowedRowLength(r.width())
+	tab.SetOutputMirror(w)
+	tab.Style().Options.SeparateColumns = true
+	tab.SetStyle(r.Style)
+
+	// the rest after the PR and OK columns, paddings and separators
+	rest := r.width() - 25 - 2 - 4*3 - 1
+	if rest < 20 {
+		rest = 20
+	}
+	tab.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, WidthMax: 25},
+		{Number: 2, WidthMax: rest / 2, WidthMaxEnforcer: text.WrapSoft},
+		{Number: 4, WidthMax: rest / 2, WidthMaxEnforcer: text.WrapSoft},
+	})
+	tab.AppendHeader(table.Row{"PR", "Title", "OK", "Reasons"})
+	for _, pr := range result.PRs {
+		ok := termenv.String("✗").Foreground(r.Theme.Red)
+		if pr.Eligible {
+			ok = termenv.String("✓").Foreground(r.Theme.Green)
+		}
+		tab.AppendRow(table.Row{
+			fmt.Sprintf("%s%s", pr.Repo, numberString(pr.Number)),
+			pr.Title,
+			ok.String(),
+			strings.Join(pr.Reasons, "; "),
+		})
+	}
+	name := result.Rules.Name
+	if name == "" {
+		name = "Event"
+	}
+	tab.SetTitle(fmt.Sprintf("%s (%s - %s)", name, result.Rules.Start, result.Rules.End))
+	if len(result.PRs) > 0 {
+		tab.Render()
+	}
+
+	_, err := fmt.Fprintf(w, "%s: %s\n", termenv.String("Progress").Bold(), r.progressBar(result.Eligible, result.Rules.Target))
+	return err
+}
+
+// matchingLabels returns the labels of c which are in labels.
+func matchingLabels(c Contribution, labels []string) []string {
+	var m []string
+	for _, l := range c.Labels {
+		for _, want := range labels {
+			if strings.EqualFold(l, want) {
+				m = append(m, l)
+			}
+		}
+	}
+	return m
+}
+
+func intersect(a, b []string) []string {
+	var s []string
+	for _, v := range a {
+		if contains(b, v) {
+			s = append(s, v)
+		}
+	}
+	return s
+}
+
+func contains(s []string, v string) bool {
+	for _, w := range s {
+		if strings.EqualFold(w, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package contrib
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func TestEventRulesEvaluate(t *testing.T) {
+	rules := EventRules{
+		Start:          "2020-10-01",
+		End:            "2020-10-31",
+		Topics:         []string{"hacktoberfest"},
+		AcceptedLabels: []string{"hacktoberfest-accepted"},
+		States:         []string{"merged", "approved"},
+		InvalidLabels:  []string{"spam", "invalid"},
+	}
+	info := EventInfo{
+		Topics:   map[string][]string{"a/a": {"go", "Hacktoberfest"}, "b/b": {"go"}},
+		Approved: map[string]bool{"https://github.com/a/a/pull/4": true},
+	}
+	at := func(month time.Month, day int) time.Time { return time.Date(2020, month, day, 12, 0, 0, 0, time.UTC) }
+	pr := func(n int, repo string, created time.Time, merged bool, labels ...string) Contribution {
+		return Contribution{
+			Type: PullRequest, Number: n, Repo: repo, CreatedAt: created,
+			Closed: merged, Merged: merged, Labels: labels,
+			URL: "https://github.com/" + repo + "/pull/" + strconv.Itoa(n),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		c           Contribution
+		wantOK      bool
+		wantReasons []string
+	}{
+		{name: "merged in a topic repo", c: pr(1, "a/a", at(10, 5), true), wantOK: true, wantReasons: []string{"repo topic Hacktoberfest", "merged"}},
+		// the window includes the whole last day
+		{name: "last day", c: pr(2, "a/a", time.Date(2020, 10, 31, 23, 59, 0, 0, time.UTC), true), wantOK: true, wantReasons: []string{"repo topic Hacktoberfest", "merged"}},
+		{name: "before the event", c: pr(3, "a/a", at(9, 30), true), wantReasons: []string{"opened outside of the event"}},
+		{name: "approved", c: pr(4, "a/a", at(10, 5), false), wantOK: true, wantReasons: []string{"repo topic Hacktoberfest", "approved"}},
+		{name: "open", c: pr(5, "a/a", at(10, 5), false), wantReasons: []string{"open, not merged or approved"}},
+		{name: "no topic", c: pr(6, "b/b", at(10, 5), true), wantReasons: []string{"repo has none of the topics hacktoberfest"}},
+		// an accepted label replaces the topic and state rules
+		{name: "accepted label", c: pr(7, "b/b", at(10, 5), false, "Hacktoberfest-Accepted"), wantOK: true, wantReasons: []string{"labelled Hacktoberfest-Accepted"}},
+		{name: "spam", c: pr(8, "a/a", at(10, 5), true, "spam", "hacktoberfest-accepted"), wantReasons: []string{"labelled spam"}},
+	}
+	for _, tt := range tests {
+		result := rules.Evaluate([]Contribution{tt.c, {Type: Issue, CreatedAt: at(10, 5)}}, info)
+		if len(result.PRs) != 1 {
+			t.Fatalf("%s: got %d PRs, want 1 as issues are skipped", tt.name, len(result.PRs))
+		}
+		got := result.PRs[0]
+		if got.Eligible != tt.wantOK || !reflect.DeepEqual(got.Reasons, tt.wantReasons) {
+			t.Errorf("%s: eligible %v, reasons %q, want %v, %q", tt.name, got.Eligible, got.Reasons, tt.wantOK, tt.wantReasons)
+		}
+		if wantCount := map[bool]int{true: 1}[tt.wantOK]; result.Eligible != wantCount {
+			t.Errorf("%s: Eligible = %d, want %d", tt.name, result.Eligible, wantCount)
+		}
+	}
+}
+
+func TestRenderEventNarrow(t *testing.T) {
+	result := EventResult{
+		Rules: EventRules{Start: "2020-10-01", End: "2020-10-31", Target: 4},
+		PRs:   []EventPR{{Contribution: Contribution{Type: PullRequest, Repo: "a/a", Number: 1, Title: "Fix the crash on start"}, Eligible: true, Reasons: []string{"merged"}}},
+	}
+	var b bytes.Buffer
+	// the title and reasons columns keep a minimum width
+	if err := (TableRenderer{Style: table.StyleLight, Width: 20}).RenderEvent(&b, result); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b.Bytes(), []byte("Fix the crash")) || !bytes.Contains(b.Bytes(), []byte("crash on")) {
+		t.Errorf("RenderEvent() = %s, want the title wrapped at 10", b.String())
+	}
+}
@@ -0,0 +1,339 @@
+package contrib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
+)
+
+// Reasons an open pull request is stuck.
+const (
+	// StuckDraft is a draft, which nobody is expected to review yet.
+	StuckDraft     = "draft"
+	StuckConflicts = "has conflicts"
+	StuckCI        = "waiting on CI"
+	StuckAuthor    = "waiting on author"
+	StuckReview    = "waiting on review"
+	// StuckPending is used while GitHub still computes the mergeable state,
+	// which may hide conflicts, so no reason can be given yet.
+	StuckPending = "pending"
+)
+
+// FollowUp is an open pull request with what it is waiting for.
+type FollowUp struct {
+	Contribution
+	UpdatedAt time.Time `json:"updated_at"`
+	// LastCommenter is the login of the last human who commented on or
+	// reviewed the pull request, "" if nobody did.
+	LastCommenter string `json:"last_commenter,omitempty"`
+	// LastByAuthor is set if the last comment is by the author.
+	LastByAuthor bool `json:"last_by_author"`
+	// CI is the combined state of the commit statuses and check runs of the
+	// head commit: success, failure, pending, or "" if there are none.
+	CI string `json:"ci,omitempty"`
+	// ReviewDecision is approved, changes_requested, or "" if nobody
+	// approved or requested changes.
+	ReviewDecision string `json:"review_decision,omitempty"`
+	// Mergeable is the mergeable state of GitHub, e.g. clean, dirty (has
+	// conflicts), blocked or behind, or pending while GitHub computes it.
+	Mergeable string `json:"mergeable"`
+	// Draft is set if the pull request is not ready for review.
+	Draft bool   `json:"draft,omitempty"`
+	Stuck string `json:"stuck"`
+}
+
+// FetchFollowUps returns the open pull requests of account, the least
+// recently updated first.
+func (c *Client) FetchFollowUps(ctx context.Context, account string) ([]FollowUp, error) {
+	issues, err := c.searchIssues(ctx, "author:"+account+" is:pr is:open")
+	if err != nil {
+		return nil, err
+	}
+
+	followUps := make([]FollowUp, 0, len(issues))
+	for _, i := range issues {
+		f := FollowUp{Contribution: issueContribution(i), UpdatedAt: i.GetUpdatedAt()}
+		if err := c.followUp(ctx, account, &f); err != nil {
+			return nil, fmt.Errorf("failed to get the state of %s#%d: %w", f.Repo, f.Number, err)
+		}
+		f.Stuck = f.stuck()
+		followUps = append(followUps, f)
+	}
+	sort.SliceStable(followUps, func(i, j int) bool {
+		return followUps[i].UpdatedAt.Before(followUps[j].UpdatedAt)
+	})
+	return followUps, nil
+}
+
+// followUp looks up the comments, reviews, CI and mergeable state of f.
+func (c *Client) followUp(ctx context.Context, account string, f *FollowUp) error {
+	s := strings.SplitN(f.Repo, "/", 2)
+	if len(s) != 2 {
+		return fmt.Errorf("invalid repo: %s", f.Repo)
+	}
+	owner, repo := s[0], s[1]
+
+	var pr *github.PullRequest
+	err := retryRateLimit(ctx, func() (err error) {
+		pr, _, err = c.gc.PullRequests.Get(ctx, owner, repo, f.Number)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	f.Private = pr.GetBase().GetRepo().GetPrivate()
+	f.Mergeable = pr.GetMergeableState()
+	f.Draft = pr.GetDraft() || f.Mergeable == "draft"
+	if f.Mergeable == "" || f.Mergeable == "unknown" {
+		f.Mergeable = "pending"
+	}
+
+	var last time.Time
+	comment := func(u *github.User, t time.Time) {
+		if u.GetType() == "Bot" || t.Before(last) {
+			return
+		}
+		last = t
+		f.LastCommenter = u.GetLogin()
+		f.LastByAuthor = strings.EqualFold(u.GetLogin(), account)
+	}
+
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		var comments []*github.IssueComment
+		var resp *github.Response
+		err := retryRateLimit(ctx, func() (err error) {
+			comments, resp, err = c.gc.Issues.ListComments(ctx, owner, repo, f.Number, opts)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for _, cm := range comments {
+			comment(cm.User, cm.GetCreatedAt())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	// the latest approval or change request of every reviewer counts
+	decisions := make(map[string]string)
+	ropts := &github.ListOptions{PerPage: 100}
+	for {
+		var reviews []*github.PullRequestReview
+		var resp *github.Response
+		err := retryRateLimit(ctx, func() (err error) {
+			reviews, resp, err = c.gc.PullRequests.ListReviews(ctx, owner, repo, f.Number, ropts)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for _, r := range reviews {
+			comment(r.User, r.GetSubmittedAt())
+			switch r.GetState() {
+			case "APPROVED", "CHANGES_REQUESTED":
+				decisions[r.GetUser().GetLogin()] = strings.ToLower(r.GetState())
+			case "DISMISSED":
+				delete(decisions, r.GetUser().GetLogin())
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		ropts.Page = resp.NextPage
+	}
+	for _, d := range decisions {
+		if d == "changes_requested" || f.ReviewDecision == "" {
+			f.ReviewDecision = d
+		}
+	}
+
+	sha := pr.GetHead().GetSHA()
+	var status *github.CombinedStatus
+	err = retryRateLimit(ctx, func() (err error) {
+		status, _, err = c.gc.Repositories.GetCombinedStatus(ctx, owner, repo, sha, nil)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	var states []string
+	if status.GetTotalCount() > 0 {
+		states = append(states, status.GetState())
+	}
+	copts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		var runs *github.ListCheckRunsResults
+		var resp *github.Response
+		err := retryRateLimit(ctx, func() (err error) {
+			runs, resp, err = c.gc.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, copts)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for _, r := range runs.CheckRuns {
+			states = append(states, checkRunState(r))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		copts.Page = resp.NextPage
+	}
+	f.CI = combineStates(states)
+	return nil
+}
+
+// checkRunState maps a check run to the states of commit statuses.
+func checkRunState(r *github.CheckRun) string {
+	if r.GetStatus() != "completed" {
+		return "pending"
+	}
+	switch r.GetConclusion() {
+	case "success", "neutral", "skipped":
+		return "success"
+	default:
+		return "failure"
+	}
+}
+
+// combineStates returns failure if any state is failure or error, else
+// pending if any is pending, else success, or "" if there are none.
+func combineStates(states []string) string {
+	combined := ""
+	for _, s := range states {
+		switch s {
+		case "failure", "error":
+			return "failure"
+		case "pending":
+			combined = "pending"
+		default:
+			if combined == "" {
+				combined = "success"
+			}
+		}
+	}
+	return combined
+}
+
+// redactedCommenter replaces the logins of other commenters in redacted
+// follow-ups.
+const redactedCommenter = "someone"
+
+// RedactFollowUps returns redacted copies of the follow-ups. Besides the
+// items, the logins of the last commenters are removed, as they are other
+// people's.
+func (r Redactor) RedactFollowUps(followUps []FollowUp) []FollowUp {
+	if r.Level == "" {
+		return followUps
+	}
+	items := make([]Contribution, len(followUps))
+	for i, f := range followUps {
+		items[i] = f.Contribution
+	}
+	redacted := make([]FollowUp, len(followUps))
+	for i, c := range r.Redact(items) {
+		f := followUps[i]
+		f.Contribution = c
+		switch {
+		case f.LastByAuthor:
+			f.LastCommenter = ""
+		case f.LastCommenter != "":
+			f.LastCommenter = redactedCommenter
+		}
+		redacted[i] = f
+	}
+	return redacted
+}
+
+// stuck returns why the pull request is not merged yet.
+func (f FollowUp) stuck() string {
+	switch {
+	case f.Draft:
+		return StuckDraft
+	case f.Mergeable == "pending":
+		return StuckPending
+	case f.Mergeable == "dirty":
+		return StuckConflicts
+	case f.CI == "failure" || f.CI == "pending":
+		return StuckCI
+	case f.ReviewDecision == "changes_requested", f.LastCommenter != "" && !f.LastByAuthor:
+		return StuckAuthor
+	default:
+		return StuckReview
+	}
+}
+
+// RenderFollowUps writes the open pull requests with how long they have
+// been idle as of now.
+func (r TableRenderer) RenderFollowUps(w io.Writer, followUps []FollowUp, now time.Time) error {
+	tab := table.NewWriter()
+	tab.SetAllowedRowLength(r.width())
+	tab.SetOutputMirror(w)
+	tab.Style().Options.SeparateColumns = true
+	tab.SetStyle(r.Style)
+
+	// the rest after the other columns, paddings and separators
+	rest := r.width() - 30 - 5 - 15 - 8 - 17 - 9 - 17 - 8*3 - 1
+	if rest < 10 {
+		rest = 10
+	}
+	tab.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, WidthMax: 30},
+		{Number: 2, WidthMax: rest, WidthMaxEnforcer: text.WrapSoft},
+		{Number: 3, Align: text.AlignRight},
+		{Number: 4, WidthMax: 15},
+	})
+	tab.AppendHeader(table.Row{"PR", "Title", "Idle", "Last comment", "CI", "Review", "Mergeable", "Stuck"})
+	for _, f := range followUps {
+		last := f.LastCommenter
+		switch {
+		case f.LastByAuthor:
+			last = "you"
+		case last == "":
+			last = "-"
+		}
+		stuck := termenv.String(f.Stuck)
+		switch f.Stuck {
+		case StuckAuthor, StuckConflicts:
+			stuck = stuck.Foreground(r.Theme.Red)
+		case StuckCI:
+			stuck = stuck.Foreground(r.Theme.Yellow)
+		case StuckPending, StuckDraft:
+			// not a reason yet, or nothing to follow up, left uncolored
+		default:
+			stuck = stuck.Foreground(r.Theme.Green)
+		}
+		tab.AppendRow(table.Row{
+			fmt.Sprintf("%s%s", f.Repo, numberString(f.Number)),
+			f.Title,
+			durationString(r, now.Sub(f.UpdatedAt)),
+			last,
+			dashIfEmpty(f.CI),
+			dashIfEmpty(strings.Replace(f.ReviewDecision, "_", " ", -1)),
+			dashIfEmpty(f.Mergeable),
+			stuck.String(),
+		})
+	}
+	tab.SetTitle(fmt.Sprintf("Your %d open PRs, least recently updated first", len(followUps)))
+	tab.Render()
+	return nil
+}
+
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,28 @@
+package contrib
+
+import "testing"
+
+func TestFollowUpStuck(t *testing.T) {
+	tests := []struct {
+		name string
+		f    FollowUp
+		want string
+	}{
+		{name: "draft", f: FollowUp{Draft: true, Mergeable: "draft"}, want: StuckDraft},
+		// a draft is not waiting on anything even while the mergeable
+		// state is computed or CI fails
+		{name: "pending draft", f: FollowUp{Draft: true, Mergeable: "pending", CI: "failure"}, want: StuckDraft},
+		{name: "pending", f: FollowUp{Mergeable: "pending"}, want: StuckPending},
+		{name: "conflicts", f: FollowUp{Mergeable: "dirty", CI: "failure"}, want: StuckConflicts},
+		{name: "ci", f: FollowUp{Mergeable: "blocked", CI: "pending"}, want: StuckCI},
+		{name: "changes requested", f: FollowUp{Mergeable: "blocked", ReviewDecision: "changes_requested"}, want: StuckAuthor},
+		{name: "comment by someone else", f: FollowUp{Mergeable: "clean", LastCommenter: "bob"}, want: StuckAuthor},
+		{name: "comment by the author", f: FollowUp{Mergeable: "clean", LastCommenter: "alice", LastByAuthor: true}, want: StuckReview},
+		{name: "no comments", f: FollowUp{Mergeable: "clean", CI: "success"}, want: StuckReview},
+	}
+	for _, tt := range tests {
+		if got := tt.f.stuck(); got != tt.want {
+			t.Errorf("%s: stuck() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,384 @@
+package contrib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+	"golang.org/x/oauth2"
+)
+
+// DefaultHost is the host name of github.com.
+const DefaultHost = "github.com"
+
+// Client is a GitHub API client.
+type Client struct {
+	gc *github.Client
+}
+
+// NewClient returns a client authenticating with token against host, which
+// is either DefaultHost or a GitHub Enterprise host name.
+func NewClient(ctx context.Context, token, host string) (*Client, error) {
+	c := oauth2.NewClient(ctx, oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	))
+	if host == "" || host == DefaultHost {
+		return &Client{gc: github.NewClient(c)}, nil
+	}
+
+	gc, err := github.NewEnterpriseClient("https://"+host+"/api/v3/", "https://"+host+"/api/uploads/", c)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{gc: gc}, nil
+}
+
+// maxRateLimitWait is the longest time a search waits for the rate limit to
+// reset before giving up.
+const maxRateLimitWait = 2 * time.Minute
+
+// maintainerAssociations are the author associations of maintainer comments
+// and reviews.
+var maintainerAssociations = []string{"OWNER", "MEMBER", "COLLABORATOR"}
+
+// GitHubFetcher fetches the issues and pull requests authored by Account,
+// and the pull requests it reviewed if Reviews is set.
+type GitHubFetcher struct {
+	Client  *Client
+	Account string
+	// Reviews also fetches the reviewed pull requests and looks up when
+	// Account first reviewed each, one API call each.
+	Reviews bool
+	// ResponseTimes looks up the first maintainer response of every issue
+	// and pull request, which costs one or two API calls each. Comments and
+	// reviews by Account do not count, as it may be a maintainer itself.
+	ResponseTimes bool
+	// Sizes looks up the lines changed by every pull request, one API call
+	// each.
+	Sizes bool
+}
+
+// Queries returns the searches Fetch runs, the authored items first.
+func (f GitHubFetcher) Queries() []string {
+	q := []string{"author:" + f.Account}
+	if f.Reviews {
+		q = append(q, "is:pr reviewed-by:"+f.Account+" -author:"+f.Account)
+	}
+	return q
+}
+
+// Fetch implements Fetcher.
+func (f GitHubFetcher) Fetch(ctx context.Context) ([]Contribution, error) {
+	queries := f.Queries()
+	contributions, err := f.Client.SearchIssues(ctx, queries[0])
+	if err != nil {
+		return nil, err
+	}
+	if f.ResponseTimes {
+		for i := range contributions {
+			t, err := f.Client.FirstResponse(ctx, contributions[i], f.Account)
+			if err != nil {
+				return nil, err
+			}
+			contributions[i].FirstResponseAt = t
+		}
+	}
+	if f.Sizes {
+		for i := range contributions {
+			n, err := f.Client.LinesChanged(ctx, contributions[i])
+			if err != nil {
+				return nil, err
+			}
+			contributions[i].LinesChanged = n
+		}
+	}
+	if !f.Reviews {
+		return contributions, nil
+	}
+
+	reviews, err := f.Client.SearchIssues(ctx, queries[1])
+	if err != nil {
+		return nil, err
+	}
+	for i := range reviews {
+		reviews[i].Type = Review
+		t, err := f.Client.ReviewedAt(ctx, reviews[i], f.Account)
+		if err != nil {
+			return nil, err
+		}
+		reviews[i].ReviewedAt = t
+	}
+	return append(contributions, reviews...), nil
+}
+
+// SearchIssues returns every issue and pull request matching the search
+// query. Merged pull requests and private repos are looked up with more
+// searches, as the search result does not tell them.
+func (c *Client) SearchIssues(ctx context.Context, query string) ([]Contribution, error) {
+	issues, err := c.searchIssues(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := c.searchIssues(ctx, query+" is:pr is:merged")
+	if err != nil {
+		return nil, err
+	}
+	mergedURLs := make(map[string]bool, len(merged))
+	for _, i := range merged {
+		mergedURLs[i.GetHTMLURL()] = true
+	}
+	private, err := c.searchIssues(ctx, query+" is:private")
+	if err != nil {
+		return nil, err
+	}
+	privateURLs := make(map[string]bool, len(private))
+	for _, i := range private {
+		privateURLs[i.GetHTMLURL()] = true
+	}
+
+	var contributions []Contribution
+	for _, i := range issues {
+		c := issueContribution(i)
+		c.Merged = mergedURLs[c.URL]
+		if c.Merged {
+			c.MergedAt = i.ClosedAt
+		}
+		c.Private = privateURLs[c.URL]
+		contributions = append(contributions, c)
+	}
+
+	return contributions, nil
+}
+
+// issueContribution converts a search result. Merged is not known from it.
+func issueContribution(i *github.Issue) Contribution {
+	t := Issue
+	if i.IsPullRequest() {
+		t = PullRequest
+	}
+	var labels []string
+	for _, l := range i.Labels {
+		labels = append(labels, l.GetName())
+	}
+	return Contribution{
+		Type:      t,
+		Number:    i.GetNumber(),
+		Title:     i.GetTitle(),
+		Repo:      repoFromURL(i.GetRepositoryURL()),
+		CreatedAt: i.GetCreatedAt(),
+		Closed:    i.ClosedAt != nil,
+		URL:       i.GetHTMLURL(),
+		Labels:    labels,
+		ClosedAt:  i.ClosedAt,
+	}
+}
+
+// LinesChanged returns the number of lines added and deleted by a pull
+// request, or nil for other types.
+func (c *Client) LinesChanged(ctx context.Context, contribution Contribution) (*int, error) {
+	s := strings.SplitN(contribution.Repo, "/", 2)
+	if len(s) != 2 || contribution.Type != PullRequest || contribution.Number == 0 {
+		return nil, nil
+	}
+	var pr *github.PullRequest
+	err := retryRateLimit(ctx, func() (err error) {
+		pr, _, err = c.gc.PullRequests.Get(ctx, s[0], s[1], contribution.Number)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s#%d: %w", contribution.Repo, contribution.Number, err)
+	}
+	n := pr.GetAdditions() + pr.GetDeletions()
+	return &n, nil
+}
+
+// FirstResponse returns the time of the first comment or review by a
+// maintainer of the repository other than account on an issue or pull
+// request, or nil if there is none.
+func (c *Client) FirstResponse(ctx context.Context, contribution Contribution, account string) (*time.Time, error) {
+	s := strings.SplitN(contribution.Repo, "/", 2)
+	if len(s) != 2 || contribution.Number == 0 {
+		return nil, nil
+	}
+	owner, repo := s[0], s[1]
+
+	var first *time.Time
+	earlier := func(t *time.Time, association string, user *github.User) {
+		if t == nil || !contains(maintainerAssociations, association) || strings.EqualFold(user.GetLogin(), account) {
+			return
+		}
+		if first == nil || t.Before(*first) {
+			first = t
+		}
+	}
+
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		var comments []*github.IssueComment
+		var resp *github.Response
+		err := retryRateLimit(ctx, func() (err error) {
+			comments, resp, err = c.gc.Issues.ListComments(ctx, owner, repo, contribution.Number, opts)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the comments of %s#%d: %w", contribution.Repo, contribution.Number, err)
+		}
+		for _, cm := range comments {
+			earlier(cm.CreatedAt, cm.GetAuthorAssociation(), cm.GetUser())
+		}
+		// comments are in the order they were created
+		if first != nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	if contribution.Type != PullRequest {
+		return first, nil
+	}
+	err := c.listReviews(ctx, contribution, func(r *github.PullRequestReview) {
+		earlier(r.SubmittedAt, r.GetAuthorAssociation(), r.GetUser())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return first, nil
+}
+
+// ReviewedAt returns the time of the first review by login of a pull
+// request, or nil if there is none.
+func (c *Client) ReviewedAt(ctx context.Context, contribution Contribution, login string) (*time.Time, error) {
+	var first *time.Time
+	err := c.listReviews(ctx, contribution, func(r *github.PullRequestReview) {
+		if r.SubmittedAt == nil || !strings.EqualFold(r.GetUser().GetLogin(), login) {
+			return
+		}
+		if first == nil || r.SubmittedAt.Before(*first) {
+			first = r.SubmittedAt
+		}
+	})
+	return first, err
+}
+
+// listReviews calls f with every review of a pull request.
+func (c *Client) listReviews(ctx context.Context, contribution Contribution, f func(*github.PullRequestReview)) error {
+	s := strings.SplitN(contribution.Repo, "/", 2)
+	if len(s) != 2 || contribution.Number == 0 {
+		return nil
+	}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		var reviews []*github.PullRequestReview
+		var resp *github.Response
+		err := retryRateLimit(ctx, func() (err error) {
+			reviews, resp, err = c.gc.PullRequests.ListReviews(ctx, s[0], s[1], contribution.Number, opts)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get the reviews of %s#%d: %w", contribution.Repo, contribution.Number, err)
+		}
+		for _, r := range reviews {
+			f(r)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// retryRateLimit calls f until it does not fail with a rate limit error,
+// waiting for the reset unless that takes longer than maxRateLimitWait.
+func retryRateLimit(ctx context.Context, f func() error) error {
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		wait, ok := rateLimitWait(err)
+		if !ok || wait > maxRateLimitWait {
+			return err
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// searchIssues runs a search through all pages. When the rate limit is hit
+// it waits for the reset, unless that takes longer than maxRateLimitWait.
+func (c *Client) searchIssues(ctx context.Context, query string) ([]*github.Issue, error) {
+	opts := github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	// pagenation
+	var issues []*github.Issue
+	for {
+		var result *github.IssuesSearchResult
+		var resp *github.Response
+		err := retryRateLimit(ctx, func() (err error) {
+			result, resp, err = c.gc.Search.Issues(ctx, query, &opts)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, result.Issues...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+		if resp.Rate.Remaining == 0 {
+			wait := time.Until(resp.Rate.Reset.Time)
+			if wait > maxRateLimitWait {
+				return nil, fmt.Errorf("the search rate limit is exhausted until %s", resp.Rate.Reset.Time.Format(time.RFC3339))
+			}
+			if err := sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return issues, nil
+}
+
+// rateLimitWait returns how long to wait before retrying if err is a rate
+// limit error.
+func rateLimitWait(err error) (time.Duration, bool) {
+	var rle *github.RateLimitError
+	if errors.As(err, &rle) {
+		return time.Until(rle.Rate.Reset.Time), true
+	}
+	var are *github.AbuseRateLimitError
+	if errors.As(err, &are) {
+		if are.RetryAfter != nil {
+			return *are.RetryAfter, true
+		}
+		return time.Minute, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// repoFromURL returns "owner/repo" from a repository API URL.
+func repoFromURL(url string) string {
+	s := strings.Split(url, "/")
+	if len(s) < 2 {
+		return url
+	}
+	return strings.Join(s[len(s)-2:], "/")
+}
@@ -0,0 +1,274 @@
+package contrib
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+)
+
+// newTestClient returns a client of a server answering with handler.
+func newTestClient(t *testing.T, handler http.Handler) (*Client, func()) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	gc := github.NewClient(nil)
+	u, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gc.BaseURL = u
+	return &Client{gc: gc}, server.Close
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSearchIssuesMergedAt(t *testing.T) {
+	closed := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	issue := func(n int) map[string]interface{} {
+		return map[string]interface{}{
+			"number":         n,
+			"html_url":       "https://github.com/a/a/pull/" + strconv.Itoa(n),
+			"repository_url": "https://api.github.com/repos/a/a",
+			"closed_at":      closed,
+			"pull_request":   map[string]interface{}{},
+		}
+	}
+	client, done := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		items := []interface{}{}
+		switch q := r.URL.Query().Get("q"); {
+		case strings.HasSuffix(q, "is:merged"):
+			items = append(items, issue(1))
+		case strings.HasSuffix(q, "is:private"):
+		default:
+			items = append(items, issue(1), issue(2))
+		}
+		writeJSON(t, w, map[string]interface{}{"total_count": len(items), "items": items})
+	}))
+	defer done()
+
+	contributions, err := client.SearchIssues(context.Background(), "author:alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contributions) != 2 {
+		t.Fatalf("got %d contributions, want 2", len(contributions))
+	}
+	if c := contributions[0]; !c.Merged || c.MergedAt == nil || !c.MergedAt.Equal(closed) {
+		t.Errorf("merged PR: Merged %v, MergedAt %v, want true, %s", c.Merged, c.MergedAt, closed)
+	}
+	if c := contributions[1]; c.Merged || c.MergedAt != nil || c.ClosedAt == nil {
+		t.Errorf("closed PR: Merged %v, MergedAt %v, ClosedAt %v, want false, nil, %s", c.Merged, c.MergedAt, c.ClosedAt, closed)
+	}
+}
+
+func TestFirstResponse(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2021, 3, 1, hour, 0, 0, 0, time.UTC) }
+	entry := func(login, association string, hour int, timeKey string) map[string]interface{} {
+		return map[string]interface{}{
+			"user":               map[string]interface{}{"login": login},
+			"author_association": association,
+			timeKey:              at(hour),
+		}
+	}
+	client, done := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/a/a/issues/1/comments":
+			writeJSON(t, w, []interface{}{
+				// the author's own comments and ones of non-maintainers
+				// are not responses
+				entry("Alice", "OWNER", 1, "created_at"),
+				entry("bob", "CONTRIBUTOR", 2, "created_at"),
+				entry("carol", "MEMBER", 5, "created_at"),
+			})
+		case "/repos/a/a/pulls/1/reviews":
+			writeJSON(t, w, []interface{}{
+				entry("alice", "OWNER", 0, "submitted_at"),
+				entry("dave", "COLLABORATOR", 4, "submitted_at"),
+				entry("alice", "OWNER", 6, "submitted_at"),
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer done()
+
+	ctx := context.Background()
+	pr := Contribution{Type: PullRequest, Repo: "a/a", Number: 1}
+	tests := []struct {
+		name string
+		c    Contribution
+		want time.Time
+	}{
+		{name: "issue", c: Contribution{Type: Issue, Repo: "a/a", Number: 1}, want: at(5)},
+		{name: "pull request", c: pr, want: at(4)},
+	}
+	for _, tt := range tests {
+		got, err := client.FirstResponse(ctx, tt.c, "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got == nil || !got.Equal(tt.want) {
+			t.Errorf("%s: FirstResponse() = %v, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	got, err := client.ReviewedAt(ctx, pr, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || !got.Equal(at(0)) {
+		t.Errorf("ReviewedAt() = %v, want %s", got, at(0))
+	}
+}
+
+func TestLookupRepos(t *testing.T) {
+	var (
+		mu                  sync.Mutex
+		inFlight, maxFlight int
+		requested           []string
+	)
+	client, done := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxFlight {
+			maxFlight = inFlight
+		}
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		switch {
+		case r.URL.Path == "/repos/gone/gone":
+			http.NotFound(w, r)
+		case strings.HasSuffix(r.URL.Path, "/commits"):
+			writeJSON(t, w, []interface{}{})
+		default:
+			writeJSON(t, w, map[string]interface{}{"stargazers_count": 5, "default_branch": "main"})
+		}
+	}))
+	defer done()
+
+	repos := []string{"gone/gone", "group/sub/project"}
+	for i := 0; i < 10; i++ {
+		repos = append(repos, "a/"+strconv.Itoa(i))
+	}
+	infos := make(RepoInfos)
+	if err := client.LookupRepos(context.Background(), repos, infos); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(infos) != len(repos) {
+		t.Errorf("got %d infos, want %d", len(infos), len(repos))
+	}
+	if !infos["gone/gone"].Missing || !infos["group/sub/project"].Missing {
+		t.Errorf("gone/gone and group/sub/project are not missing: %+v", infos)
+	}
+	if info := infos["a/3"]; info.Missing || info.Stars != 5 {
+		t.Errorf("a/3 = %+v, want 5 stars", info)
+	}
+	for _, p := range requested {
+		if strings.HasPrefix(p, "/repos/group/") {
+			t.Errorf("requested %s for a nested group", p)
+		}
+	}
+	if maxFlight > repoLookups {
+		t.Errorf("%d requests at once, want at most %d", maxFlight, repoLookups)
+	}
+}
+
+func TestLookupReposError(t *testing.T) {
+	client, done := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/repos/broken/") {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/commits") {
+			writeJSON(t, w, []interface{}{})
+			return
+		}
+		writeJSON(t, w, map[string]interface{}{"default_branch": "main"})
+	}))
+	defer done()
+
+	infos := make(RepoInfos)
+	err := client.LookupRepos(context.Background(), []string{"a/a", "broken/broken"}, infos)
+	if err == nil || !strings.Contains(err.Error(), "failed to look up broken/broken") {
+		t.Errorf("error = %v, want the lookup of broken/broken to fail", err)
+	}
+	if _, ok := infos["broken/broken"]; ok {
+		t.Error("the failed repo was added")
+	}
+}
+
+func TestSearchIssuesRateLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		reset        time.Duration
+		wantErr      string
+		wantRequests int
+	}{
+		{name: "short wait", reset: -time.Second, wantRequests: 3},
+		{name: "long wait", reset: time.Hour, wantErr: "the search rate limit is exhausted until", wantRequests: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client, done := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				reset := strconv.FormatInt(time.Now().Add(tt.reset).Unix(), 10)
+				switch {
+				case requests == 1 && tt.reset < 0:
+					// a rate limit error is retried after the reset
+					w.Header().Set("X-RateLimit-Remaining", "0")
+					w.Header().Set("X-RateLimit-Reset", reset)
+					http.Error(w, `{"message": "API rate limit exceeded"}`, http.StatusForbidden)
+				case r.URL.Query().Get("page") == "":
+					// the limit is used up by the first page
+					w.Header().Set("X-RateLimit-Remaining", "0")
+					w.Header().Set("X-RateLimit-Reset", reset)
+					w.Header().Set("Link", `<`+r.URL.Path+`?page=2>; rel="next"`)
+					writeJSON(t, w, map[string]interface{}{"items": []interface{}{map[string]interface{}{"number": 1}}})
+				default:
+					writeJSON(t, w, map[string]interface{}{"items": []interface{}{map[string]interface{}{"number": 2}}})
+				}
+			}))
+			defer done()
+
+			issues, err := client.searchIssues(context.Background(), "author:alice")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				if requests != tt.wantRequests {
+					t.Errorf("%d requests, want %d", requests, tt.wantRequests)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(issues) != 2 || requests != tt.wantRequests {
+				t.Errorf("got %d issues with %d requests, want 2 with %d", len(issues), requests, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package contrib
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
+	"gopkg.in/yaml.v2"
+)
+
+// GoalPeriods are the periods a goal can be set for.
+var GoalPeriods = []string{"month", "quarter", "year"}
+
+// GoalsFile lists contribution goals.
+type GoalsFile struct {
+	Goals []Goal `yaml:"goals"`
+}
+
+// Goal is a number of contributions to reach in every period, e.g. 5 merged
+// pull requests per quarter.
+type Goal struct {
+	Name   string `yaml:"name"`
+	Type   Type   `yaml:"type"`
+	State  string `yaml:"state"`
+	Target int    `yaml:"target"`
+	Period string `yaml:"period"`
+	// Repos and ExcludeRepos are "owner/repo" patterns like "kubernetes/*".
+	Repos        []string `yaml:"repos"`
+	ExcludeRepos []string `yaml:"exclude_repos"`
+	// Foundations are the foundations assigned by a Catalog, e.g. "CNCF".
+	Foundations []string `yaml:"foundations"`
+}
+
+// GoalProgress is the progress of a goal in the current period.
+type GoalProgress struct {
+	Goal
+	Start time.Time
+	End   time.Time
+	Done  int
+	// Expected is the count needed by now to reach the target at an even
+	// pace, Projected the count at the end of the period at the current
+	// pace.
+	Expected  float64
+	Projected float64
+}
+
+// LoadGoals parses a YAML goals file and validates it.
+func LoadGoals(path string) (GoalsFile, error) {
+	var goals GoalsFile
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return goals, err
+	}
+	if err := yaml.UnmarshalStrict(b, &goals); err != nil {
+		return goals, fmt.Errorf("failed to parse goals %s: %w", path, err)
+	}
+	if err := goals.validate(); err != nil {
+		return goals, fmt.Errorf("invalid goals %s: %w", path, err)
+	}
+	return goals, nil
+}
+
+func (f GoalsFile) validate() error {
+	var problems []string
+	for i, g := range f.Goals {
+		prefix := fmt.Sprintf("goal %d", i+1)
+		if g.Name != "" {
+			prefix += fmt.Sprintf(" (%s)", g.Name)
+		}
+
+		switch g.Type {
+		case Issue, PullRequest, Review, Commit, Ledger:
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown type %q (valid: issue, pr, review, commit, ledger)", prefix, g.Type))
+		}
+		switch g.State {
+		case "", "open", "closed", "merged":
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown state %q (valid: open, closed, merged)", prefix, g.State))
+		}
+		if g.Target <= 0 {
+			problems = append(problems, prefix+": target must be positive")
+		}
+		if !contains(GoalPeriods, g.Period) {
+			problems = append(problems, fmt.Sprintf("%s: unknown period %q (valid: %s)", prefix, g.Period, strings.Join(GoalPeriods, ", ")))
+		}
+		for _, p := range append(g.Repos, g.ExcludeRepos...) {
+			if _, err := path.Match(p, ""); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid repo pattern %q", prefix, p))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// HasType reports whether any goal counts contributions of type t.
+func (f GoalsFile) HasType(t Type) bool {
+	for _, g := range f.Goals {
+		if g.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFoundations reports whether any goal is limited to foundations, which
+// requires a Catalog.
+func (f GoalsFile) HasFoundations() bool {
+	for _, g := range f.Goals {
+		if len(g.Foundations) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Progress returns the progress of every goal in the period containing now.
+func (f GoalsFile) Progress(contributions []Contribution, now time.Time) []GoalProgress {
+	progress := make([]GoalProgress, 0, len(f.Goals))
+	for _, g := range f.Goals {
+		progress = append(progress, g.Progress(contributions, now))
+	}
+	return progress
+}
+
+// Progress returns the progress of the goal in the period containing now.
+// Contributions count in the period they reached the state of the goal in,
+// e.g. pull requests in the one they were merged in.
+func (g Goal) Progress(contributions []Contribution, now time.Time) GoalProgress {
+	p := GoalProgress{Goal: g}
+	p.Start, p.End = periodBounds(g.Period, now)
+	for _, c := range contributions {
+		t := c.eventTime(g.State)
+		if t.Before(p.Start) || !t.Before(p.End) {
+			continue
+		}
+		if g.matches(c) {
+			p.Done++
+		}
+	}
+
+	elapsed := float64(now.Sub(p.Start)) / float64(p.End.Sub(p.Start))
+	p.Expected = float64(g.Target) * elapsed
+	p.Projected = float64(p.Done)
+	if elapsed > 0 {
+		p.Projected = float64(p.Done) / elapsed
+	}
+	return p
+}
+
+func (g Goal) matches(c Contribution) bool {
+	if c.Type != g.Type {
+		return false
+	}
+	if g.State != "" && c.State() != g.State {
+		return false
+	}
+	if len(g.Repos) > 0 && !matchRepo(g.Repos, c.Repo) {
+		return false
+	}
+	if len(g.Foundations) > 0 && !contains(g.Foundations, c.Foundation) {
+		return false
+	}
+	return !matchRepo(g.ExcludeRepos, c.Repo)
+}
+
+func matchRepo(patterns []string, repo string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, repo); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Status returns "done", "on track" or "behind".
+func (p GoalProgress) Status() string {
+	switch {
+	case p.Done >= p.Target:
+		return "done"
+	case float64(p.Done) >= p.Expected:
+		return "on track"
+	default:
+		return "behind"
+	}
+}
+
+// Behind reports whether the goal is behind schedule.
+func (p GoalProgress) Behind() bool {
+	return p.Status() == "behind"
+}
+
+// periodLabel names the period, e.g. "2020-Q3".
+func (p GoalProgress) periodLabel() string {
+	switch p.Period {
+	case "month":
+		return p.Start.Format("2006-01")
+	case "quarter":
+		return fmt.Sprintf("%d-Q%d", p.Start.Year(), int(p.Start.Month()-1)/3+1)
+	default:
+		return p.Start.Format("2006")
+	}
+}
+
+// periodBounds returns the start and the end of the period containing now
+// in UTC.
+func periodBounds(period string, now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	switch period {
+	case "month":
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	case "quarter":
+		m := (now.Month()-1)/3*3 + 1
+		start := time.Date(now.Year(), m, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 3, 0)
+	default:
+		start := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, 0)
+	}
+}
+
+// RenderGoals writes the progress of the goals.
+func (r TableRenderer) RenderGoals(w io.Writer, progress []GoalProgress) error {
+	tab := table.NewWriter()
+	tab.SetAllowedRowLength(r.width())
+	tab.SetOutputMirror(w)
+	tab.Style().Options.SeparateColumns = true
+	tab.SetStyle(r.Style)
+
+	tab.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 4, Align: text.AlignRight},
+		{Number: 5, Align: text.AlignRight},
+	})
+	tab.AppendHeader(table.Row{"Goal", "Period", "Progress", "Expected", "Projected", "Status"})
+	for _, p := range progress {
+		status := termenv.String(p.Status())
+		switch p.Status() {
+		case "done":
+			status = status.Foreground(r.Theme.Green)
+		case "on track":
+			status = status.Foreground(r.Theme.Yellow)
+		default:
+			status = status.Foreground(r.Theme.Red)
+		}
+		tab.AppendRow(table.Row{
+			p.goalName(),
+			p.periodLabel(),
+			r.progressBar(p.Done, p.Target),
+			fmt.Sprintf("%.1f", p.Expected),
+			fmt.Sprintf("%.1f", p.Projected),
+			status.String(),
+		})
+	}
+	tab.SetTitle("Your goals")
+	tab.Render()
+	return nil
+}
+
+// goalName returns the name of the goal, or a description if it has none.
+func (g Goal) goalName() string {
+	if g.Name != "" {
+		return g.Name
+	}
+	s := string(g.Type)
+	if g.State != "" {
+		s = g.State + " " + s
+	}
+	return fmt.Sprintf("%s: %d per %s", s, g.Target, g.Period)
+}
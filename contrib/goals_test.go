@@ -0,0 +1,46 @@
+package contrib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGoalProgress(t *testing.T) {
+	now := time.Date(2021, 5, 15, 0, 0, 0, 0, time.UTC)
+	date := func(m time.Month, d int) *time.Time {
+		t := time.Date(2021, m, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	contributions := []Contribution{
+		// created in the last quarter, merged in this one
+		{Type: PullRequest, Repo: "a/a", CreatedAt: *date(3, 20), Closed: true, Merged: true, ClosedAt: date(4, 2), MergedAt: date(4, 2), Foundation: "CNCF"},
+		// created in this quarter, still open
+		{Type: PullRequest, Repo: "b/b", CreatedAt: *date(4, 10)},
+		// created and merged in the last quarter
+		{Type: PullRequest, Repo: "b/b", CreatedAt: *date(1, 10), Closed: true, Merged: true, ClosedAt: date(2, 1), MergedAt: date(2, 1)},
+		// reviewed in this quarter, created in the last one
+		{Type: Review, Repo: "c/c", CreatedAt: *date(3, 1), ReviewedAt: date(4, 20)},
+		// reviewed in the last quarter, created in this one
+		{Type: Review, Repo: "c/c", CreatedAt: *date(4, 1), ReviewedAt: date(3, 31)},
+		// no review time, e.g. from an older report
+		{Type: Review, Repo: "c/c", CreatedAt: *date(5, 1)},
+	}
+
+	tests := []struct {
+		goal Goal
+		want int
+	}{
+		{goal: Goal{Type: PullRequest, Target: 1, Period: "quarter"}, want: 1},
+		{goal: Goal{Type: PullRequest, State: "merged", Target: 1, Period: "quarter"}, want: 1},
+		{goal: Goal{Type: PullRequest, State: "merged", Target: 1, Period: "year"}, want: 2},
+		{goal: Goal{Type: Review, Target: 1, Period: "quarter"}, want: 2},
+		{goal: Goal{Type: PullRequest, State: "merged", Target: 1, Period: "quarter", Foundations: []string{"cncf"}}, want: 1},
+		{goal: Goal{Type: PullRequest, Target: 1, Period: "year", Foundations: []string{"Apache"}}, want: 0},
+	}
+	for _, tt := range tests {
+		p := tt.goal.Progress(contributions, now)
+		if p.Done != tt.want {
+			t.Errorf("%s: Done = %d, want %d", tt.goal.goalName(), p.Done, tt.want)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package contrib
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// LedgerKinds are the contribution kinds accepted in a ledger file.
+var LedgerKinds = []string{
+	"talk",
+	"docs",
+	"translation",
+	"mentoring",
+	"committee",
+	"security",
+	"other",
+}
+
+// LedgerFile is a manually maintained list of contributions which are not
+// visible on any forge.
+type LedgerFile struct {
+	Entries []LedgerEntry `yaml:"contributions" json:"contributions"`
+}
+
+type LedgerEntry struct {
+	Date    string `yaml:"date" json:"date"`
+	Project string `yaml:"project" json:"project"`
+	Title   string `yaml:"title" json:"title"`
+	Kind    string `yaml:"kind" json:"kind"`
+	URL     string `yaml:"url" json:"url"`
+}
+
+// LedgerFetcher reads the contributions of a ledger file.
+type LedgerFetcher struct {
+	Path string
+}
+
+// Fetch implements Fetcher.
+func (f LedgerFetcher) Fetch(ctx context.Context) ([]Contribution, error) {
+	ledger, err := LoadLedger(f.Path)
+	if err != nil {
+		return nil, err
+	}
+	return ledger.Contributions(), nil
+}
+
+// Contributions converts the ledger entries into contributions.
+func (l LedgerFile) Contributions() []Contribution {
+	var contributions []Contribution
+	for _, e := range l.Entries {
+		// the date has been validated already
+		date, _ := time.Parse("2006-01-02", e.Date)
+		contributions = append(contributions, Contribution{
+			Type:      Ledger,
+			Title:     e.Title,
+			Repo:      e.Project,
+			CreatedAt: date,
+			Kind:      e.Kind,
+			URL:       e.URL,
+		})
+	}
+
+	return contributions
+}
+
+// LoadLedger parses a YAML or JSON ledger file and validates it.
+func LoadLedger(path string) (LedgerFile, error) {
+	var ledger LedgerFile
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ledger, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&ledger)
+	default:
+		err = yaml.UnmarshalStrict(b, &ledger)
+	}
+	if err != nil {
+		return ledger, fmt.Errorf("failed to parse ledger %s: %w", path, err)
+	}
+
+	if err := ledger.validate(); err != nil {
+		return ledger, fmt.Errorf("invalid ledger %s: %w", path, err)
+	}
+	return ledger, nil
+}
+
+// validate checks every entry against the ledger schema and reports all
+// problems at once.
+func (l LedgerFile) validate() error {
+	var problems []string
+	for i, e := range l.Entries {
+		prefix := fmt.Sprintf("entry %d", i+1)
+		if e.Title != "" {
+			prefix += fmt.Sprintf(" (%s)", e.Title)
+		}
+
+		if e.Date == "" {
+			problems = append(problems, prefix+": date is required")
+		} else if _, err := time.Parse("2006-01-02", e.Date); err != nil {
+			problems = append(problems, prefix+": date must be YYYY-MM-DD: "+e.Date)
+		}
+		if e.Project == "" {
+			problems = append(problems, prefix+": project is required")
+		}
+		if e.Title == "" {
+			problems = append(problems, prefix+": title is required")
+		}
+		if !isLedgerKind(e.Kind) {
+			problems = append(problems, fmt.Sprintf("%s: unknown kind %q (valid: %s)", prefix, e.Kind, strings.Join(LedgerKinds, ", ")))
+		}
+		if e.URL != "" {
+			u, err := url.Parse(e.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				problems = append(problems, prefix+": url must be an absolute http(s) URL: "+e.URL)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func isLedgerKind(kind string) bool {
+	for _, k := range LedgerKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package contrib
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// separators used in the git log format, chosen so that they never show
+	// up in commit messages.
+	gitFieldSep  = "\x1f"
+	gitRecordSep = "\x1e"
+)
+
+// LocalFetcher finds git clones below Dirs and returns the commits authored
+// or co-authored by one of Emails or Names. The repository name is taken
+// from the origin remote. A commit found in several clones or worktrees of a
+// repository is returned once.
+type LocalFetcher struct {
+	Dirs   []string
+	Emails []string
+	Names  []string
+
+	// Warnings receives skipped repositories and unreadable directories if
+	// set.
+	Warnings io.Writer
+}
+
+// Fetch implements Fetcher.
+func (f LocalFetcher) Fetch(ctx context.Context) ([]Contribution, error) {
+	if len(f.Dirs) == 0 {
+		return nil, errors.New("local source needs at least one directory")
+	}
+	if len(f.Emails) == 0 && len(f.Names) == 0 {
+		return nil, errors.New("local source needs an author email or name")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("local source needs git in PATH: %w", err)
+	}
+
+	var repos []string
+	for _, dir := range f.Dirs {
+		found, err := f.findGitRepositories(dir)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, found...)
+	}
+
+	var contributions []Contribution
+	// "owner/repo hash" of the commits already returned
+	seen := make(map[string]bool)
+	for _, dir := range repos {
+		project, err := originProject(ctx, dir)
+		if err != nil {
+			f.warnf("skipping %s: %s\n", dir, err)
+			continue
+		}
+		commits, err := f.authoredCommits(ctx, dir, project, seen)
+		if err != nil {
+			return nil, err
+		}
+		contributions = append(contributions, commits...)
+	}
+
+	return contributions, nil
+}
+
+func (f LocalFetcher) warnf(format string, a ...interface{}) {
+	if f.Warnings != nil {
+		fmt.Fprintf(f.Warnings, format, a...)
+	}
+}
+
+// findGitRepositories returns every directory below root which contains a
+// .git entry. Repositories are not descended into.
+func (f LocalFetcher) findGitRepositories(root string) ([]string, error) {
+	var repos []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			f.warnf("%s\n", err)
+			if info != nil && info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		// .git is a file for worktrees and submodules
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			repos = append(repos, path)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repos, nil
+}
+
+// originProject returns the "owner/repo" name of the origin remote of the
+// repository in dir.
+func originProject(ctx context.Context, dir string) (string, error) {
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "config", "--get", "remote.origin.url").Output()
+	if err != nil {
+		return "", errors.New("no origin remote")
+	}
+
+	return parseRemoteURL(strings.TrimSpace(string(out)))
+}
+
+// parseRemoteURL extracts "owner/repo" from https, ssh and scp-like remote
+// URLs. The owner is the full group path for nested groups, e.g.
+// "group/subgroup/repo" on GitLab.
+func parseRemoteURL(url string) (string, error) {
+	u := strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+	} else if i := strings.Index(u, ":"); i >= 0 {
+		// scp-like syntax: git@github.com:owner/repo
+		u = u[:i] + "/" + u[i+1:]
+	}
+
+	s := strings.Split(u, "/")
+	if len(s) < 3 {
+		return "", fmt.Errorf("cannot parse remote url: %s", url)
+	}
+	return strings.Join(s[1:], "/"), nil
+}
+
+// shortstatPattern matches the insertions or deletions of git log
+// --shortstat.
+var shortstatPattern = regexp.MustCompile(`(\d+) (insertion|deletion)`)
+
+// authoredCommits returns the commits reachable from HEAD in dir which were
+// authored or co-authored by one of the fetcher's identities. Commits in seen
+// are skipped, the returned ones are added to it.
+func (f LocalFetcher) authoredCommits(ctx context.Context, dir, project string, seen map[string]bool) ([]Contribution, error) {
+	// the record separator comes first and the field separator last, so that
+	// the --shortstat line is the last field of its commit
+	format := gitRecordSep + strings.Join([]string{"%H", "%an", "%ae", "%at", "%s", "%b"}, gitFieldSep) + gitFieldSep
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "log", "--no-merges", "--shortstat", "--format="+format).Output()
+	if err != nil {
+		// empty repositories have no HEAD
+		f.warnf("skipping %s: git log failed: %s\n", dir, err)
+		return nil, nil
+	}
+
+	var contributions []Contribution
+	for _, record := range strings.Split(string(out), gitRecordSep) {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), gitFieldSep)
+		if len(fields) != 7 {
+			continue
+		}
+		key := project + " " + fields[0]
+		if seen[key] {
+			continue
+		}
+		if !f.isOwnIdentity(fields[1], fields[2]) && !f.hasOwnCoAuthor(fields[5]) {
+			continue
+		}
+		ts, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		seen[key] = true
+		lines := 0
+		for _, m := range shortstatPattern.FindAllStringSubmatch(fields[6], -1) {
+			n, _ := strconv.Atoi(m[1])
+			lines += n
+		}
+		contributions = append(contributions, Contribution{
+			Type:         Commit,
+			Title:        fields[4],
+			Repo:         project,
+			CreatedAt:    time.Unix(ts, 0).UTC(),
+			LinesChanged: &lines,
+		})
+	}
+
+	return contributions, nil
+}
+
+// isOwnIdentity returns true if name or email matches one of the fetcher's
+// names or emails.
+func (f LocalFetcher) isOwnIdentity(name, email string) bool {
+	for _, e := range f.Emails {
+		if strings.EqualFold(e, email) {
+			return true
+		}
+	}
+	for _, n := range f.Names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasOwnCoAuthor returns true if the commit message body has a
+// Co-authored-by trailer for one of the fetcher's identities.
+func (f LocalFetcher) hasOwnCoAuthor(body string) bool {
+	sc := bufio.NewScanner(bytes.NewBufferString(body))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if !strings.HasPrefix(strings.ToLower(line), "co-authored-by:") {
+			continue
+		}
+		ident := strings.TrimSpace(line[len("co-authored-by:"):])
+		name, email := ident, ""
+		if i := strings.Index(ident, "<"); i >= 0 {
+			name = strings.TrimSpace(ident[:i])
+			email = strings.TrimSuffix(strings.TrimSpace(ident[i+1:]), ">")
+		}
+		if f.isOwnIdentity(name, email) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,78 @@
+package contrib
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{url: "https://github.com/owner/repo.git", want: "owner/repo"},
+		{url: "https://github.com/owner/repo", want: "owner/repo"},
+		{url: "https://github.com/owner/repo/", want: "owner/repo"},
+		{url: "ssh://git@github.com/owner/repo.git", want: "owner/repo"},
+		{url: "ssh://git@example.com:2222/owner/repo.git", want: "owner/repo"},
+		{url: "git@github.com:owner/repo.git", want: "owner/repo"},
+		{url: "git@github.com:owner/repo", want: "owner/repo"},
+		{url: "https://gitlab.com/group/subgroup/repo.git", want: "group/subgroup/repo"},
+		{url: "https://github.com/repo", wantErr: true},
+		{url: "repo", wantErr: true},
+		{url: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseRemoteURL(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseRemoteURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseRemoteURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestLocalFetcherDeduplicatesClones(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not in PATH")
+	}
+	root, err := ioutil.TempDir("", "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_NOSYSTEM=1", "HOME="+root)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s: %s", args, err, out)
+		}
+	}
+	upstream := filepath.Join(root, "upstream")
+	if err := os.Mkdir(upstream, 0755); err != nil {
+		t.Fatal(err)
+	}
+	git(upstream, "init", "-q")
+	git(upstream, "remote", "add", "origin", "https://github.com/owner/repo.git")
+	git(upstream, "-c", "user.name=Alice", "-c", "user.email=alice@example.com", "commit", "-q", "--allow-empty", "-m", "first")
+	git(root, "clone", "-q", upstream, filepath.Join(root, "clone"))
+	git(filepath.Join(root, "clone"), "remote", "set-url", "origin", "https://github.com/owner/repo.git")
+	git(upstream, "worktree", "add", "-q", "--detach", filepath.Join(root, "worktree"))
+
+	got, err := LocalFetcher{Dirs: []string{root}, Emails: []string{"alice@example.com"}}.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Repo != "owner/repo" || got[0].Title != "first" {
+		t.Errorf("Fetch() = %+v, want the commit once", got)
+	}
+}
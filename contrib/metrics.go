@@ -0,0 +1,68 @@
+package contrib
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// OpenMetricsContentType is the content type of WriteOpenMetrics' output.
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+type metricKey struct {
+	account, repo, typ, state string
+}
+
+// WriteOpenMetrics writes the contribution counts of the reports as
+// OpenMetrics gauges labelled by account, repo, type and state. The output
+// can also be read by the textfile collector of node_exporter.
+func WriteOpenMetrics(w io.Writer, reports ...Report) error {
+	counts := make(map[metricKey]int)
+	for _, r := range reports {
+		for _, c := range r.Items {
+			counts[metricKey{r.Account, c.Repo, string(c.Type), c.State()}]++
+		}
+	}
+	keys := make([]metricKey, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.account != b.account {
+			return a.account < b.account
+		}
+		if a.repo != b.repo {
+			return a.repo < b.repo
+		}
+		if a.typ != b.typ {
+			return a.typ < b.typ
+		}
+		return a.state < b.state
+	})
+
+	var b strings.Builder
+	b.WriteString("# HELP oss_contributions Number of contributions.\n")
+	b.WriteString("# TYPE oss_contributions gauge\n")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "oss_contributions{account=%s,repo=%s,type=%s,state=%s} %d\n",
+			labelValue(k.account), labelValue(k.repo), labelValue(k.typ), labelValue(k.state), counts[k])
+	}
+	b.WriteString("# HELP oss_contributions_fetched_timestamp_seconds Time the contributions were fetched.\n")
+	b.WriteString("# TYPE oss_contributions_fetched_timestamp_seconds gauge\n")
+	for _, r := range reports {
+		fmt.Fprintf(&b, "oss_contributions_fetched_timestamp_seconds{account=%s} %d\n",
+			labelValue(r.Account), r.FetchedAt.Unix())
+	}
+	b.WriteString("# EOF\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// labelValue quotes and escapes a label value.
+func labelValue(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,96 @@
+package contrib
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Redaction levels, each including the ones before.
+const (
+	// RedactTitles replaces titles with "owner/repo#number".
+	RedactTitles = "titles"
+	// RedactRepos also replaces the names of private and internal repos with
+	// a salted hash.
+	RedactRepos = "repos"
+	// RedactAggregate also allows only aggregates to be shown.
+	RedactAggregate = "aggregate"
+)
+
+// RedactLevels are the redaction levels in increasing strictness.
+var RedactLevels = []string{RedactTitles, RedactRepos, RedactAggregate}
+
+// Redactor removes personal and internal information from contributions so
+// that reports can be shared publicly.
+type Redactor struct {
+	// Level is one of RedactLevels, nothing is redacted if empty.
+	Level string
+	// Salt is hashed with the names of private and internal repos. Hashes
+	// stay the same across runs with the same salt.
+	Salt string
+	// Internal are "owner/repo" patterns like "myorg/*" of repos hashed in
+	// addition to the private ones.
+	Internal []string
+}
+
+// Validate checks the level and that a salt is given if repos are hashed.
+func (r Redactor) Validate() error {
+	if r.Level != "" && !contains(RedactLevels, r.Level) {
+		return fmt.Errorf("unknown redaction level: %s (valid: %s)", r.Level, strings.Join(RedactLevels, ", "))
+	}
+	if r.hashRepos() && r.Salt == "" {
+		return errors.New("redacting repos needs a salt to hash their names with")
+	}
+	for _, p := range r.Internal {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid repo pattern %q", p)
+		}
+	}
+	return nil
+}
+
+// AggregateOnly reports whether only aggregates may be shown.
+func (r Redactor) AggregateOnly() bool {
+	return r.Level == RedactAggregate
+}
+
+func (r Redactor) hashRepos() bool {
+	return r.Level == RedactRepos || r.Level == RedactAggregate
+}
+
+// Redact returns redacted copies of the contributions. Summaries of the
+// result are consistent with its rows, as they are computed from the same
+// redacted repo names.
+func (r Redactor) Redact(contributions []Contribution) []Contribution {
+	if r.Level == "" {
+		return contributions
+	}
+	redacted := make([]Contribution, len(contributions))
+	for i, c := range contributions {
+		if r.hashRepos() && (c.Private || matchRepo(r.Internal, c.Repo)) {
+			c.Repo = r.hash(c.Repo)
+			c.URL = ""
+			// the topics, stars and labels may tell which repo it is
+			c.RepoInfo = nil
+			c.Labels = nil
+		}
+		c.Title = c.Repo + numberString(c.Number)
+		if c.Number == 0 {
+			// a ledger URL or a commit title may tell what it was about
+			c.Title = c.Repo + " " + string(c.Type)
+			c.URL = ""
+		}
+		redacted[i] = c
+	}
+	return redacted
+}
+
+// hash returns "private/" and the first 12 hex digits of the salted SHA-256
+// of repo.
+func (r Redactor) hash(repo string) string {
+	sum := sha256.Sum256([]byte(r.Salt + "\x00" + repo))
+	return "private/" + hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,151 @@
+package contrib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	public := Contribution{
+		Type:     PullRequest,
+		Number:   1,
+		Title:    "Fix the crash",
+		Repo:     "a/public",
+		URL:      "https://github.com/a/public/pull/1",
+		Labels:   []string{"bug"},
+		RepoInfo: &RepoInfo{Stars: 10},
+	}
+	private := Contribution{
+		Type:     Issue,
+		Number:   2,
+		Title:    "Secret plans",
+		Repo:     "a/private",
+		URL:      "https://github.com/a/private/issues/2",
+		Labels:   []string{"team/secret"},
+		RepoInfo: &RepoInfo{Stars: 1},
+		Private:  true,
+	}
+	internal := Contribution{
+		Type:  Ledger,
+		Title: "Talk about our platform",
+		Repo:  "myorg/platform",
+		URL:   "https://example.com/talk",
+	}
+	titled := func(c Contribution, title string) Contribution {
+		c.Title = title
+		return c
+	}
+	hashed := func(c Contribution, title string) Contribution {
+		c.Repo = Redactor{Salt: "s"}.hash(c.Repo)
+		c.Title = c.Repo + title
+		c.URL = ""
+		c.Labels = nil
+		c.RepoInfo = nil
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		level string
+		want  []Contribution
+	}{
+		{
+			name: "none",
+			want: []Contribution{public, private, internal},
+		},
+		{
+			name:  "titles",
+			level: RedactTitles,
+			want: []Contribution{
+				titled(public, "a/public#1"),
+				titled(private, "a/private#2"),
+				// the URL of an item without a number may tell what it was
+				titled(Contribution{Type: Ledger, Repo: "myorg/platform"}, "myorg/platform ledger"),
+			},
+		},
+		{
+			name:  "repos",
+			level: RedactRepos,
+			want: []Contribution{
+				titled(public, "a/public#1"),
+				hashed(private, "#2"),
+				hashed(internal, " ledger"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Redactor{Level: tt.level, Salt: "s", Internal: []string{"myorg/*"}}
+			got := r.Redact([]Contribution{public, private, internal})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Redact() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactHash(t *testing.T) {
+	a := Redactor{Salt: "a"}
+	if a.hash("x/y") != a.hash("x/y") {
+		t.Error("hash is not stable for the same salt")
+	}
+	if a.hash("x/y") == (Redactor{Salt: "b"}).hash("x/y") {
+		t.Error("hash does not depend on the salt")
+	}
+	if got := a.hash("x/y"); len(got) != len("private/")+12 {
+		t.Errorf("hash() = %q, want private/ and 12 hex digits", got)
+	}
+}
+
+func TestRedactorValidate(t *testing.T) {
+	tests := []struct {
+		r       Redactor
+		wantErr bool
+	}{
+		{r: Redactor{}},
+		{r: Redactor{Level: RedactTitles}},
+		{r: Redactor{Level: RedactRepos, Salt: "s"}},
+		{r: Redactor{Level: RedactRepos}, wantErr: true},
+		{r: Redactor{Level: RedactAggregate}, wantErr: true},
+		{r: Redactor{Level: "all"}, wantErr: true},
+		{r: Redactor{Level: RedactRepos, Salt: "s", Internal: []string{"[a"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := tt.r.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%+v.Validate() error = %v, wantErr %v", tt.r, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRedactFollowUps(t *testing.T) {
+	followUps := []FollowUp{
+		{Contribution: Contribution{Type: PullRequest, Number: 1, Title: "Fix the crash", Repo: "a/a"}, LastCommenter: "bob"},
+		{Contribution: Contribution{Type: PullRequest, Number: 2, Title: "Add docs", Repo: "a/a"}, LastCommenter: "alice", LastByAuthor: true},
+		{Contribution: Contribution{Type: PullRequest, Number: 3, Title: "Secret", Repo: "a/private", Private: true}},
+	}
+
+	if got := (Redactor{}).RedactFollowUps(followUps); !reflect.DeepEqual(got, followUps) {
+		t.Errorf("RedactFollowUps() without a level = %+v, want them unchanged", got)
+	}
+
+	got := Redactor{Level: RedactRepos, Salt: "salt"}.RedactFollowUps(followUps)
+	want := []struct {
+		title, commenter string
+		byAuthor         bool
+	}{
+		{title: "a/a#1", commenter: redactedCommenter},
+		{title: "a/a#2", commenter: "", byAuthor: true},
+		{title: got[2].Repo + "#3", commenter: ""},
+	}
+	for i, w := range want {
+		if got[i].Title != w.title || got[i].LastCommenter != w.commenter || got[i].LastByAuthor != w.byAuthor {
+			t.Errorf("follow-up %d = %q, %q, %v, want %q, %q, %v", i, got[i].Title, got[i].LastCommenter, got[i].LastByAuthor, w.title, w.commenter, w.byAuthor)
+		}
+	}
+	if got[2].Repo == "a/private" {
+		t.Error("the private repo is not hashed")
+	}
+	if followUps[0].LastCommenter != "bob" {
+		t.Error("the follow-ups were modified")
+	}
+}
@@ -0,0 +1,404 @@
+package contrib
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// TableRenderer renders contributions and summaries as a table.
+type TableRenderer struct {
+	Theme Theme
+	Style table.Style
+	// Width is the max output width, 80 if 0.
+	Width int
+	// Columns are the IDs of the visible columns, in order. The defaults
+	// of the table are used if empty.
+	Columns []string
+	// SortBy is the ID of the column to sort by, which does not need to be
+	// visible. Tables are sorted by their first default column if empty.
+	SortBy string
+	// Hyperlinks makes titles OSC 8 hyperlinks to the item URL.
+	Hyperlinks bool
+
+	// columnWidth is the max width of the column being formatted, 0 if it
+	// has none. Progress bars are shortened to fit it.
+	columnWidth int
+}
+
+// Placeholders of OSC 8 hyperlinks while the table is laid out. They are
+// zero-width runes, so go-pretty lays the cell out as plain text without
+// carrying any escape sequence over line breaks or borders. linkStart opens
+// the next link, linkContinue reopens the current one on a wrapped line and
+// linkEnd closes it before the cell border.
+const (
+	linkStart    = '\u200b'
+	linkContinue = '\u200c'
+	linkEnd      = '\u200d'
+)
+
+// RenderList writes one row per contribution.
+func (r TableRenderer) RenderList(w io.Writer, contributions []Contribution) error {
+	defaults := []string{"year", "title", "repo", "pr"}
+	for _, c := range contributions {
+		if c.Kind != "" {
+			defaults = append(defaults, "kind")
+			break
+		}
+	}
+
+	rows := make([]map[string]interface{}, 0, len(contributions))
+	for _, c := range contributions {
+		row := make(map[string]interface{})
+		for _, col := range columns {
+			if col.item != nil {
+				row[col.ID] = col.item(c)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return r.render(w, fmt.Sprintf("Your %d contributions", len(rows)), defaults, rows)
+}
+
+// RenderSummary writes one row per summary. group is the name the summaries
+// were grouped by, its column shows the summary key.
+func (r TableRenderer) RenderSummary(w io.Writer, group string, summaries []Summary) error {
+	if _, err := lookupColumn(group); err != nil {
+		return err
+	}
+	defaults := []string{group, "issue_num", "pr_num"}
+	var issues, prs, commits, others, merged, closed, responded bool
+	for _, s := range summaries {
+		issues = issues || s.Issues > 0
+		prs = prs || s.PRs > 0
+		commits = commits || s.Commits > 0
+		others = others || s.Others > 0
+		merged = merged || s.TimeToMerge.Count > 0
+		closed = closed || s.TimeToClose.Count > 0
+		responded = responded || s.TimeToFirstResponse.Count > 0
+	}
+	if commits {
+		defaults = append(defaults, "commit_num")
+	}
+	if others {
+		defaults = append(defaults, "other_num")
+	}
+	if merged {
+		defaults = append(defaults, "merge_median")
+	}
+	if closed {
+		defaults = append(defaults, "close_median")
+	}
+	if responded {
+		defaults = append(defaults, "response_median")
+	}
+	// the bars would be empty without any issues or PRs, e.g. for a ledger
+	if issues {
+		defaults = append(defaults, "issue_percent")
+	}
+	if prs {
+		defaults = append(defaults, "pr_percent")
+	}
+
+	rows := summaryRows(group, summaries)
+
+	var title string
+	switch group {
+	case "year":
+		title = "Your yearly contribution"
+	case "repo":
+		title = fmt.Sprintf("Your %d contributed projects", len(rows))
+	default:
+		title = fmt.Sprintf("Your contribution by %s", group)
+	}
+	return r.render(w, title, defaults, rows)
+}
+
+// RenderSlowest writes the repository summaries returned by SlowestRepos,
+// sorted by the median time to merge unless SortBy is set.
+func (r TableRenderer) RenderSlowest(w io.Writer, summaries []Summary) error {
+	defaults := []string{"repo", "pr_num", "merge_median", "merge_p90", "close_median", "response_median"}
+	if r.SortBy == "" {
+		r.SortBy = "-merge_median"
+	}
+	title := fmt.Sprintf("Your %d slowest projects to merge", len(summaries))
+	return r.render(w, title, defaults, summaryRows("repo", summaries))
+}
+
+// summaryRows returns the raw column values of the summaries, with the key in
+// the column of group.
+func summaryRows(group string, summaries []Summary) []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(summaries))
+	for _, s := range summaries {
+		row := map[string]interface{}{group: s.Key}
+		for _, col := range columns {
+			if col.summary != nil {
+				row[col.ID] = col.summary(s)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (r TableRenderer) render(w io.Writer, title string, defaults []string, rows []map[string]interface{}) error {
+	ids := r.Columns
+	if len(ids) == 0 {
+		ids = defaults
+	}
+	cols := make([]Column, 0, len(ids))
+	for _, id := range ids {
+		col, err := lookupColumn(id)
+		if err != nil {
+			return err
+		}
+		cols = append(cols, col)
+	}
+
+	sortBy := r.SortBy
+	if sortBy == "" {
+		sortBy = defaults[0]
+	}
+	// a leading "-" sorts in descending order
+	desc := strings.HasPrefix(sortBy, "-")
+	sortBy = strings.TrimPrefix(sortBy, "-")
+	if _, err := lookupColumn(sortBy); err != nil {
+		return err
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if desc {
+			i, j = j, i
+		}
+		a, aok := rows[i][sortBy]
+		b, bok := rows[j][sortBy]
+		if !aok || !bok {
+			return !aok && bok
+		}
+		return less(a, b)
+	})
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tab := table.NewWriter()
+	tab.SetAllowedRowLength(r.width())
+	tab.Style().Options.SeparateColumns = true
+	tab.SetStyle(r.Style)
+
+	twidth := r.tableWidth(cols, tab.Style().Options.SeparateColumns)
+	ratios := 0.0
+	for _, col := range cols {
+		ratios += col.WidthRatio
+	}
+	if ratios < 1 {
+		ratios = 1
+	}
+	var configs []table.ColumnConfig
+	headers := table.Row{}
+	for i, col := range cols {
+		cfg := table.ColumnConfig{Number: i + 1}
+		if col.WidthRatio > 0 {
+			cfg.WidthMax = int(float64(twidth) * col.WidthRatio / ratios)
+		}
+		if col.AlignLeft {
+			cfg.Align = text.AlignLeft
+			cfg.AlignHeader = text.AlignLeft
+		}
+		if col.ID == "title" && r.Hyperlinks {
+			cfg.WidthMaxEnforcer = wrapUnlinked
+		}
+		configs = append(configs, cfg)
+		headers = append(headers, col.Name)
+	}
+	tab.SetColumnConfigs(configs)
+	tab.AppendHeader(headers)
+
+	var links []string
+	for _, row := range rows {
+		out := make(table.Row, 0, len(cols))
+		for i, col := range cols {
+			v, ok := row[col.ID]
+			url, _ := row["url"].(string)
+			switch {
+			case !ok:
+				out = append(out, "")
+			case col.ID == "title" && r.Hyperlinks && url != "":
+				links = append(links, url)
+				out = append(out, markLink(fmt.Sprint(v), configs[i].WidthMax))
+			case col.format != nil:
+				cr := r
+				cr.columnWidth = configs[i].WidthMax
+				out = append(out, col.format(cr, v))
+			default:
+				out = append(out, v)
+			}
+		}
+		tab.AppendRow(out)
+	}
+
+	tab.SetTitle(title)
+	out := tab.Render()
+	if len(links) > 0 {
+		out = replaceLinks(out, links)
+	}
+	_, err := fmt.Fprintln(w, out)
+	return err
+}
+
+// replaceLinks replaces the placeholders with OSC 8 sequences to links in
+// the order of the rows. A link is closed at the end of a line in case the
+// row was cut off before its end.
+func replaceLinks(s string, links []string) string {
+	var b strings.Builder
+	n := -1
+	open := false
+	for _, r := range s {
+		switch r {
+		case linkStart:
+			n++
+			b.WriteString(hyperlink(links[n]))
+			open = true
+		case linkContinue:
+			b.WriteString(hyperlink(links[n]))
+			open = true
+		case linkEnd:
+			b.WriteString(hyperlink(""))
+			open = false
+		case '\n':
+			if open {
+				b.WriteString(hyperlink(""))
+				open = false
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if open {
+		b.WriteString(hyperlink(""))
+	}
+	return b.String()
+}
+
+// markLink wraps s to width like go-pretty would and marks every line as a
+// link. Placeholder runes in s are dropped.
+func markLink(s string, width int) string {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case linkStart, linkContinue, linkEnd:
+			return -1
+		}
+		return r
+	}, s)
+	lines := []string{s}
+	if width > 0 {
+		lines = strings.Split(text.WrapText(s, width), "\n")
+	}
+	for i, l := range lines {
+		start := linkContinue
+		if i == 0 {
+			start = linkStart
+		}
+		lines[i] = string(start) + l + string(linkEnd)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapUnlinked wraps cells like go-pretty, except for the links wrapped by
+// markLink, as go-pretty would count their placeholders as characters.
+func wrapUnlinked(s string, width int) string {
+	if strings.ContainsRune(s, linkStart) {
+		return s
+	}
+	return text.WrapText(s, width)
+}
+
+// hyperlink returns the OSC 8 sequence starting a link to url, or ending a
+// link if url is empty.
+func hyperlink(url string) string {
+	return "\x1b]8;;" + url + "\x1b\\"
+}
+
+func (r TableRenderer) width() int {
+	if r.Width == 0 {
+		return 80
+	}
+	return r.Width
+}
+
+// tableWidth returns the width left for the columns with a WidthRatio.
+func (r TableRenderer) tableWidth(cols []Column, separators bool) int {
+	var sw int
+	if separators {
+		sw = 1
+	}
+
+	twidth := r.width()
+	for _, col := range cols {
+		// the header of a fixed width column is never wrapped
+		w := col.Width
+		if col.WidthRatio == 0 && len(col.Name) > w {
+			w = len(col.Name)
+		}
+		twidth -= 2 + sw + w
+	}
+
+	return twidth
+}
+
+// RenderCSV writes one line per contribution with a header.
+func RenderCSV(w io.Writer, contributions []Contribution) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"type", "year", "title", "repo", "closed", "kind", "url", "number", "labels", "created_at", "closed_at", "merged_at", "first_response_at", "lines_changed", "score"}); err != nil {
+		return err
+	}
+	for _, c := range contributions {
+		err := cw.Write([]string{
+			string(c.Type),
+			c.Year(),
+			c.Title,
+			c.Repo,
+			strconv.FormatBool(c.Closed),
+			c.Kind,
+			c.URL,
+			strconv.Itoa(c.Number),
+			strings.Join(c.Labels, ";"),
+			c.CreatedAt.Format(time.RFC3339),
+			timeString(c.ClosedAt),
+			timeString(c.MergedAt),
+			timeString(c.FirstResponseAt),
+			intString(c.LinesChanged),
+			strconv.FormatFloat(c.Score, 'f', -1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// intString formats n, or "" if n is nil.
+func intString(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+// timeString formats t as RFC 3339, or "" if t is nil.
+func timeString(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,94 @@
+package contrib
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func TestRenderListTitle(t *testing.T) {
+	contributions := []Contribution{
+		{Type: PullRequest, Title: "Fix the crash", Repo: "a/a", CreatedAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Type: Commit, Title: "Add docs", Repo: "a/a", CreatedAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Type: Ledger, Title: "Talk", Repo: "a/a", Kind: "talk", CreatedAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	var b bytes.Buffer
+	if err := (TableRenderer{Style: table.StyleLight, Width: 120}).RenderList(&b, contributions); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "Your 3 contributions") {
+		t.Errorf("RenderList() = %s, want the title Your 3 contributions", b.String())
+	}
+}
+
+func TestRenderSummaryColumns(t *testing.T) {
+	at := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		contributions []Contribution
+		want          []string
+		wantNot       []string
+	}{
+		{
+			name:          "issues and PRs",
+			contributions: []Contribution{{Type: Issue, Repo: "a/a", CreatedAt: at}, {Type: PullRequest, Repo: "a/a", CreatedAt: at}},
+			want:          []string{"ISSUE%", "PR%"},
+			wantNot:       []string{"COMMIT COUNT", "OTHER COUNT", "SCORE%"},
+		},
+		{
+			name:          "scored issues and PRs",
+			contributions: []Contribution{{Type: Issue, Repo: "a/a", CreatedAt: at, Score: 1}, {Type: PullRequest, Repo: "a/a", CreatedAt: at, Score: 2}},
+			want:          []string{"ISSUE%", "PR%"},
+			wantNot:       []string{"SCORE%"},
+		},
+		{
+			name:          "PRs only",
+			contributions: []Contribution{{Type: PullRequest, Repo: "a/a", CreatedAt: at}},
+			want:          []string{"PR%"},
+			wantNot:       []string{"ISSUE%"},
+		},
+		{
+			name:          "ledger only",
+			contributions: []Contribution{{Type: Ledger, Repo: "a/a", Kind: "talk", CreatedAt: at}},
+			want:          []string{"OTHER COUNT"},
+			wantNot:       []string{"ISSUE%", "PR%"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			r := TableRenderer{Style: table.StyleLight, Width: 120}
+			if err := r.RenderSummary(&b, "year", SummarizeByYear(tt.contributions)); err != nil {
+				t.Fatal(err)
+			}
+			for _, h := range tt.want {
+				if !strings.Contains(b.String(), h) {
+					t.Errorf("RenderSummary() = %s, want the column %s", b.String(), h)
+				}
+			}
+			for _, h := range tt.wantNot {
+				if strings.Contains(b.String(), h) {
+					t.Errorf("RenderSummary() = %s, want no column %s", b.String(), h)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderSummaryScoreColumns(t *testing.T) {
+	at := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	contributions := []Contribution{{Type: Issue, Repo: "a/a", CreatedAt: at, Score: 1}, {Type: PullRequest, Repo: "a/a", CreatedAt: at, Score: 2}}
+	var b bytes.Buffer
+	r := TableRenderer{Style: table.StyleLight, Width: 120, Columns: []string{"year", "issue_score_percent", "pr_score_percent"}}
+	if err := r.RenderSummary(&b, "year", SummarizeByYear(contributions)); err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range []string{"ISSUE SCORE%", "PR SCORE%"} {
+		if !strings.Contains(b.String(), h) {
+			t.Errorf("RenderSummary() = %s, want the column %s", b.String(), h)
+		}
+	}
+}
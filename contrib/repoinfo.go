@@ -0,0 +1,269 @@
+package contrib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+)
+
+// RepoInfo is the metadata of a GitHub repository.
+type RepoInfo struct {
+	Stars    int      `json:"stars"`
+	Topics   []string `json:"topics,omitempty"`
+	License  string   `json:"license,omitempty"`
+	Archived bool     `json:"archived,omitempty"`
+	Fork     bool     `json:"fork,omitempty"`
+	// LastCommitAt is the time of the last commit on the default branch.
+	LastCommitAt *time.Time `json:"last_commit_at,omitempty"`
+	// Missing is set for repos which are not on GitHub or not visible.
+	Missing   bool      `json:"missing,omitempty"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// RepoInfos maps "owner/repo" to the metadata of the repository.
+type RepoInfos map[string]RepoInfo
+
+// LoadRepoInfos reads repository metadata saved by Save. A missing file is
+// an empty cache.
+func LoadRepoInfos(path string) (RepoInfos, error) {
+	infos := make(RepoInfos)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return infos, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &infos); err != nil {
+		return nil, fmt.Errorf("failed to parse repo cache %s: %w", path, err)
+	}
+	return infos, nil
+}
+
+// Save writes the metadata to path.
+func (m RepoInfos) Save(path string) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// Stale returns the sorted repos of the contributions which are not in infos
+// or were looked up more than maxAge ago.
+func (m RepoInfos) Stale(contributions []Contribution, maxAge time.Duration) []string {
+	var repos []string
+	seen := make(map[string]bool)
+	for _, c := range contributions {
+		if seen[c.Repo] {
+			continue
+		}
+		seen[c.Repo] = true
+		if info, ok := m[c.Repo]; ok && time.Since(info.FetchedAt) < maxAge {
+			continue
+		}
+		repos = append(repos, c.Repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
+
+// repoLookups is the number of repos LookupRepos looks up at once. GitHub
+// limits concurrent requests as a secondary rate limit, so it is kept low.
+const repoLookups = 4
+
+// LookupRepos looks up the metadata of the "owner/repo" repos with two API
+// calls each, repoLookups repos at once, and adds it to infos. Repos which do
+// not exist or are not GitHub repos, like nested GitLab groups, are added as
+// Missing. On an error, the repos looked up so far are added.
+//
+// The lookups are not batched: the REST API has no call for several repos,
+// and the GraphQL API which has one is not supported by the client. Looking
+// up many repos hence takes 2 calls of the hourly rate limit per repo.
+func (c *Client) LookupRepos(ctx context.Context, repos []string, infos RepoInfos) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	sem := make(chan struct{}, repoLookups)
+	for _, repo := range repos {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(repo string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			info, err := c.lookupRepo(ctx, repo)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				infos[repo] = info
+			case firstErr == nil:
+				firstErr = fmt.Errorf("failed to look up %s: %w", repo, err)
+				// the other lookups fail with the canceled context
+				cancel()
+			}
+		}(repo)
+	}
+	wg.Wait()
+	if firstErr == nil {
+		// canceled by the caller
+		firstErr = ctx.Err()
+	}
+	return firstErr
+}
+
+func (c *Client) lookupRepo(ctx context.Context, repo string) (RepoInfo, error) {
+	info := RepoInfo{FetchedAt: time.Now().UTC()}
+	s := strings.Split(repo, "/")
+	if len(s) != 2 {
+		info.Missing = true
+		return info, nil
+	}
+
+	var r *github.Repository
+	err := retryRateLimit(ctx, func() (err error) {
+		r, _, err = c.gc.Repositories.Get(ctx, s[0], s[1])
+		return err
+	})
+	if isStatus(err, http.StatusNotFound) {
+		info.Missing = true
+		return info, nil
+	}
+	if err != nil {
+		return info, err
+	}
+	info.Stars = r.GetStargazersCount()
+	info.Topics = r.Topics
+	info.License = r.GetLicense().GetSPDXID()
+	info.Archived = r.GetArchived()
+	info.Fork = r.GetFork()
+
+	var commits []*github.RepositoryCommit
+	err = retryRateLimit(ctx, func() (err error) {
+		commits, _, err = c.gc.Repositories.ListCommits(ctx, s[0], s[1], &github.CommitsListOptions{
+			SHA:         r.GetDefaultBranch(),
+			ListOptions: github.ListOptions{PerPage: 1},
+		})
+		return err
+	})
+	// an empty repository is a conflict
+	if isStatus(err, http.StatusConflict) {
+		return info, nil
+	}
+	if err != nil {
+		return info, err
+	}
+	if len(commits) > 0 {
+		t := commits[0].GetCommit().GetCommitter().GetDate()
+		info.LastCommitAt = &t
+	}
+	return info, nil
+}
+
+func isStatus(err error, status int) bool {
+	var er *github.ErrorResponse
+	return errors.As(err, &er) && er.Response != nil && er.Response.StatusCode == status
+}
+
+// Enrich sets RepoInfo of every contribution whose repo is in infos.
+func Enrich(contributions []Contribution, infos RepoInfos) {
+	for i := range contributions {
+		if info, ok := infos[contributions[i].Repo]; ok {
+			info := info
+			contributions[i].RepoInfo = &info
+		}
+	}
+}
+
+// RepoFilter selects contributions by the metadata of their repos.
+// Contributions without metadata, like ledger entries, only pass if neither
+// MinStars nor Topics is set.
+type RepoFilter struct {
+	MinStars        int
+	Topics          []string
+	ExcludeArchived bool
+}
+
+// Empty reports whether the filter selects every contribution.
+func (f RepoFilter) Empty() bool {
+	return f.MinStars == 0 && len(f.Topics) == 0 && !f.ExcludeArchived
+}
+
+// Filter returns the contributions to repos with at least MinStars stars,
+// any of Topics, and not archived if ExcludeArchived is set.
+func (f RepoFilter) Filter(contributions []Contribution) []Contribution {
+	if f.Empty() {
+		return contributions
+	}
+	var filtered []Contribution
+	for _, c := range contributions {
+		info := c.RepoInfo
+		switch {
+		case info == nil || info.Missing:
+			if f.MinStars == 0 && len(f.Topics) == 0 {
+				filtered = append(filtered, c)
+			}
+		case info.Stars < f.MinStars:
+		case len(f.Topics) > 0 && len(intersect(info.Topics, f.Topics)) == 0:
+		case f.ExcludeArchived && info.Archived:
+		default:
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// starTier returns the order of magnitude of the stars of the repo, in a
+// form which sorts as strings.
+func starTier(c Contribution) string {
+	if c.RepoInfo == nil || c.RepoInfo.Missing {
+		return "-"
+	}
+	switch s := c.RepoInfo.Stars; {
+	case s < 100:
+		return "0-99"
+	case s < 1000:
+		return "100-999"
+	case s < 10000:
+		return "1000-9999"
+	default:
+		return "10000+"
+	}
+}
+
+// repoInfoString returns f of the metadata of the repo, or "-" if there is
+// none.
+func repoInfoString(c Contribution, f func(RepoInfo) string) string {
+	if c.RepoInfo == nil || c.RepoInfo.Missing {
+		return "-"
+	}
+	if s := f(*c.RepoInfo); s != "" {
+		return s
+	}
+	return "-"
+}
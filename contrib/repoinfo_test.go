@@ -0,0 +1,100 @@
+package contrib
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRepoFilter(t *testing.T) {
+	repo := func(name string, info *RepoInfo) Contribution {
+		return Contribution{Type: PullRequest, Repo: name, RepoInfo: info}
+	}
+	items := []Contribution{
+		repo("small/go", &RepoInfo{Stars: 10, Topics: []string{"go"}}),
+		repo("big/go", &RepoInfo{Stars: 5000, Topics: []string{"Go", "cli"}}),
+		repo("big/old", &RepoInfo{Stars: 2000, Archived: true}),
+		repo("gone/gone", &RepoInfo{Missing: true}),
+		repo("unknown/unknown", nil),
+		{Type: Ledger, Title: "talk"},
+	}
+
+	tests := []struct {
+		name   string
+		filter RepoFilter
+		want   []string
+	}{
+		{
+			name: "empty",
+			want: []string{"small/go", "big/go", "big/old", "gone/gone", "unknown/unknown", ""},
+		},
+		{
+			name:   "min stars",
+			filter: RepoFilter{MinStars: 1000},
+			want:   []string{"big/go", "big/old"},
+		},
+		{
+			name:   "topic case-insensitively",
+			filter: RepoFilter{Topics: []string{"go"}},
+			want:   []string{"small/go", "big/go"},
+		},
+		{
+			name:   "exclude archived keeps items without metadata",
+			filter: RepoFilter{ExcludeArchived: true},
+			want:   []string{"small/go", "big/go", "gone/gone", "unknown/unknown", ""},
+		},
+		{
+			name:   "all",
+			filter: RepoFilter{MinStars: 1000, Topics: []string{"cli"}, ExcludeArchived: true},
+			want:   []string{"big/go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range tt.filter.Filter(items) {
+				got = append(got, c.Repo)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Filter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStarTier(t *testing.T) {
+	tests := []struct {
+		info *RepoInfo
+		want string
+	}{
+		{nil, "-"},
+		{&RepoInfo{Missing: true, Stars: 50}, "-"},
+		{&RepoInfo{Stars: 0}, "0-99"},
+		{&RepoInfo{Stars: 99}, "0-99"},
+		{&RepoInfo{Stars: 100}, "100-999"},
+		{&RepoInfo{Stars: 9999}, "1000-9999"},
+		{&RepoInfo{Stars: 10000}, "10000+"},
+	}
+	for _, tt := range tests {
+		if got := starTier(Contribution{RepoInfo: tt.info}); got != tt.want {
+			t.Errorf("starTier(%+v) = %q, want %q", tt.info, got, tt.want)
+		}
+	}
+}
+
+func TestRepoInfoString(t *testing.T) {
+	license := func(i RepoInfo) string { return i.License }
+	tests := []struct {
+		info *RepoInfo
+		want string
+	}{
+		{nil, "-"},
+		{&RepoInfo{Missing: true, License: "MIT"}, "-"},
+		{&RepoInfo{}, "-"},
+		{&RepoInfo{License: "MIT"}, "MIT"},
+	}
+	for _, tt := range tests {
+		if got := repoInfoString(Contribution{RepoInfo: tt.info}, license); got != tt.want {
+			t.Errorf("repoInfoString(%+v) = %q, want %q", tt.info, got, tt.want)
+		}
+	}
+}
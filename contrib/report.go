@@ -0,0 +1,126 @@
+package contrib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ReportSchemaVersion is bumped whenever the report format changes in an
+// incompatible way.
+const ReportSchemaVersion = 2
+
+// Report is the JSON export format.
+type Report struct {
+	SchemaVersion int       `json:"schema_version"`
+	ToolVersion   string    `json:"tool_version"`
+	Account       string    `json:"account,omitempty"`
+	Sources       []string  `json:"sources"`
+	FetchedAt     time.Time `json:"fetched_at"`
+	// Queries are the GitHub searches the items were fetched with.
+	Queries []string `json:"queries,omitempty"`
+	// Transformations are the options the items were filtered, redacted or
+	// assigned foundations with after they were fetched, e.g.
+	// "--redact titles", in the order they were applied.
+	Transformations []string       `json:"transformations,omitempty"`
+	Items           []Contribution `json:"items"`
+	// Summaries are set by WithSummaries for signed reports.
+	Summaries map[string][]Summary `json:"summaries,omitempty"`
+}
+
+// NewReport returns a report of the contributions fetched now. Queries can
+// be set afterwards.
+func NewReport(account string, sources []string, toolVersion string, contributions []Contribution) Report {
+	if contributions == nil {
+		contributions = []Contribution{}
+	}
+	return Report{
+		SchemaVersion: ReportSchemaVersion,
+		ToolVersion:   toolVersion,
+		Account:       account,
+		Sources:       sources,
+		FetchedAt:     time.Now().UTC(),
+		Items:         contributions,
+	}
+}
+
+// incompatibleSchemas explains why reports of older schema versions cannot
+// be read.
+var incompatibleSchemas = map[int]string{
+	1: "its items have the year but not the creation time, the number and the merged state",
+}
+
+// ReadReport decodes a report written by WriteJSON or WriteJSONL and checks
+// its schema version.
+func ReadReport(r io.Reader) (Report, error) {
+	var report Report
+	d := json.NewDecoder(r)
+	if err := d.Decode(&report); err != nil {
+		return report, err
+	}
+	if err := checkSchemaVersion(report.SchemaVersion); err != nil {
+		return report, err
+	}
+	for d.More() {
+		var c Contribution
+		if err := d.Decode(&c); err != nil {
+			return report, fmt.Errorf("item %d: %w", len(report.Items)+1, err)
+		}
+		report.Items = append(report.Items, c)
+	}
+	if report.Items == nil {
+		report.Items = []Contribution{}
+	}
+	return report, nil
+}
+
+func checkSchemaVersion(v int) error {
+	switch {
+	case v == ReportSchemaVersion:
+		return nil
+	case v == 0:
+		return errors.New("no schema_version, it is not a report written by export or fetch")
+	case v > ReportSchemaVersion:
+		return fmt.Errorf("schema version %d is newer than %d supported by this version of the tool, update the tool to read it", v, ReportSchemaVersion)
+	case incompatibleSchemas[v] != "":
+		return fmt.Errorf("schema version %d cannot be read as %s, export the data again with this version of the tool", v, incompatibleSchemas[v])
+	default:
+		return fmt.Errorf("schema version %d is not supported, expected %d, export the data again with this version of the tool", v, ReportSchemaVersion)
+	}
+}
+
+// WriteJSON writes the indented report.
+func (r Report) WriteJSON(w io.Writer) error {
+	output, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error formatting the json output: %s", err)
+	}
+
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
+
+// WriteJSONL writes the report without its items on the first line and then
+// one item per line.
+func (r Report) WriteJSONL(w io.Writer) error {
+	e := json.NewEncoder(w)
+	header := struct {
+		SchemaVersion   int       `json:"schema_version"`
+		ToolVersion     string    `json:"tool_version"`
+		Account         string    `json:"account,omitempty"`
+		Sources         []string  `json:"sources"`
+		FetchedAt       time.Time `json:"fetched_at"`
+		Transformations []string  `json:"transformations,omitempty"`
+	}{r.SchemaVersion, r.ToolVersion, r.Account, r.Sources, r.FetchedAt, r.Transformations}
+	if err := e.Encode(header); err != nil {
+		return err
+	}
+	for _, c := range r.Items {
+		if err := e.Encode(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package contrib
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// SizeBuckets are the names of the size buckets, see sizeBucket. "-" is
+// used for items whose size is not known, such as issues.
+var SizeBuckets = []string{"xs", "s", "m", "l", "xl"}
+
+// StarTiers are the repo popularity tiers, see starTier. "-" is used for
+// repos without metadata.
+var StarTiers = []string{"0-99", "100-999", "1000-9999", "10000+"}
+
+// ScoreWeights are the factors the score of a contribution is multiplied
+// from. Types gives the base score. All weights default to 1 for values not
+// listed.
+type ScoreWeights struct {
+	Types map[Type]float64 `yaml:"types"`
+	// States are keyed by open, closed and merged.
+	States map[string]float64 `yaml:"states"`
+	// Stars are keyed by StarTiers and Sizes by SizeBuckets, both may
+	// have "-" for the unknown ones.
+	Stars map[string]float64 `yaml:"stars"`
+	Sizes map[string]float64 `yaml:"sizes"`
+}
+
+// DefaultScoreWeights returns the weights used if none are configured.
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{
+		Types:  map[Type]float64{Issue: 1, PullRequest: 3, Review: 1, Commit: 1, Ledger: 2},
+		States: map[string]float64{"open": 1, "closed": 0.5, "merged": 2},
+		Stars:  map[string]float64{"0-99": 1, "100-999": 1.5, "1000-9999": 2, "10000+": 3},
+		Sizes:  map[string]float64{"xs": 0.5, "s": 1, "m": 1.5, "l": 2, "xl": 2.5},
+	}
+}
+
+// With returns the weights with the ones set in o replaced.
+func (w ScoreWeights) With(o ScoreWeights) ScoreWeights {
+	merged := ScoreWeights{
+		Types:  make(map[Type]float64),
+		States: make(map[string]float64),
+		Stars:  make(map[string]float64),
+		Sizes:  make(map[string]float64),
+	}
+	for _, m := range []ScoreWeights{w, o} {
+		for k, v := range m.Types {
+			merged.Types[k] = v
+		}
+		for k, v := range m.States {
+			merged.States[k] = v
+		}
+		for k, v := range m.Stars {
+			merged.Stars[k] = v
+		}
+		for k, v := range m.Sizes {
+			merged.Sizes[k] = v
+		}
+	}
+	return merged
+}
+
+// Validate checks that every weight is known and not negative.
+func (w ScoreWeights) Validate() error {
+	var problems []string
+	check := func(factor, key string, valid []string, v float64) {
+		// the weights are looked up by the exact key
+		known := false
+		for _, k := range valid {
+			known = known || k == key
+		}
+		if !known {
+			problems = append(problems, fmt.Sprintf("%s: unknown key %q (valid: %s)", factor, key, strings.Join(valid, ", ")))
+		}
+		if v < 0 {
+			problems = append(problems, fmt.Sprintf("%s %s: weight must not be negative", factor, key))
+		}
+	}
+	for k, v := range w.Types {
+		check("types", string(k), []string{"issue", "pr", "review", "commit", "ledger"}, v)
+	}
+	for k, v := range w.States {
+		check("states", k, []string{"open", "closed", "merged"}, v)
+	}
+	for k, v := range w.Stars {
+		check("stars", k, append([]string{"-"}, StarTiers...), v)
+	}
+	for k, v := range w.Sizes {
+		check("sizes", k, append([]string{"-"}, SizeBuckets...), v)
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid score weights:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// ScoreFactor is one factor of a score, e.g. the state "merged" weighted 2.
+type ScoreFactor struct {
+	Name   string  `json:"name"`
+	Value  string  `json:"value"`
+	Weight float64 `json:"weight"`
+}
+
+// Explain returns the factors of the score of the contribution, whose
+// product is the score.
+func (w ScoreWeights) Explain(c Contribution) []ScoreFactor {
+	factors := []ScoreFactor{{Name: "type", Value: string(c.Type), Weight: w.typeWeight(c.Type)}}
+	if s := c.State(); s != "" {
+		factors = append(factors, ScoreFactor{Name: "state", Value: s, Weight: weight(w.States, s)})
+	}
+	tier := starTier(c)
+	factors = append(factors, ScoreFactor{Name: "stars", Value: tier, Weight: weight(w.Stars, tier)})
+	size := sizeBucket(c)
+	factors = append(factors, ScoreFactor{Name: "size", Value: size, Weight: weight(w.Sizes, size)})
+	return factors
+}
+
+// Score returns the product of the factors of the contribution.
+func (w ScoreWeights) Score(c Contribution) float64 {
+	score := 1.0
+	for _, f := range w.Explain(c) {
+		score *= f.Weight
+	}
+	return score
+}
+
+// Assign sets Score of every contribution.
+func (w ScoreWeights) Assign(contributions []Contribution) {
+	for i := range contributions {
+		contributions[i].Score = w.Score(contributions[i])
+	}
+}
+
+// typeWeight returns the weight of t, or 1 if it has none.
+func (w ScoreWeights) typeWeight(t Type) float64 {
+	if v, ok := w.Types[t]; ok {
+		return v
+	}
+	return 1
+}
+
+// weight returns the weight of key, or 1 if it has none.
+func weight(m map[string]float64, key string) float64 {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return 1
+}
+
+// sizeBucket returns the size bucket of the lines changed by the
+// contribution, "-" if they are not known.
+func sizeBucket(c Contribution) string {
+	if c.LinesChanged == nil {
+		return "-"
+	}
+	switch n := *c.LinesChanged; {
+	case n < 10:
+		return "xs"
+	case n < 50:
+		return "s"
+	case n < 250:
+		return "m"
+	case n < 1000:
+		return "l"
+	default:
+		return "xl"
+	}
+}
+
+// RenderScores writes the factors of the score of every contribution,
+// highest score first.
+func (r TableRenderer) RenderScores(w io.Writer, contributions []Contribution, weights ScoreWeights) error {
+	sorted := make([]Contribution, len(contributions))
+	copy(sorted, contributions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return weights.Score(sorted[i]) > weights.Score(sorted[j])
+	})
+
+	tab := table.NewWriter()
+	tab.SetAllowedRowLength(r.width())
+	tab.SetOutputMirror(w)
+	tab.Style().Options.SeparateColumns = true
+	tab.SetStyle(r.Style)
+
+	// the rest after the other columns, paddings and separators
+	rest := r.width() - 25 - 9 - 9 - 12 - 7 - 6 - 7*3 - 1
+	if rest < 10 {
+		rest = 10
+	}
+	tab.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, WidthMax: 25},
+		{Number: 2, WidthMax: rest, WidthMaxEnforcer: text.WrapSoft},
+		{Number: 7, Align: text.AlignRight},
+	})
+	tab.AppendHeader(table.Row{"Item", "Title", "Type", "State", "Stars", "Size", "Score"})
+	for _, c := range sorted {
+		row := table.Row{c.Repo + numberString(c.Number), c.Title}
+		cells := map[string]string{"state": "-"}
+		for _, f := range weights.Explain(c) {
+			cells[f.Name] = fmt.Sprintf("%s ×%s", f.Value, strconv.FormatFloat(f.Weight, 'f', -1, 64))
+		}
+		for _, name := range []string{"type", "state", "stars", "size"} {
+			row = append(row, cells[name])
+		}
+		row = append(row, scoreString(r, weights.Score(c)))
+		tab.AppendRow(row)
+	}
+	tab.SetTitle(fmt.Sprintf("Scores of your %d items", len(sorted)))
+	tab.Render()
+	return nil
+}
+
+// scoreString formats a score with two decimals.
+func scoreString(_ TableRenderer, v interface{}) string {
+	f, ok := v.(float64)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
@@ -0,0 +1,103 @@
+package contrib
+
+import "testing"
+
+func TestScore(t *testing.T) {
+	lines := func(n int) *int { return &n }
+	defaults := DefaultScoreWeights()
+	partial := ScoreWeights{
+		Types:  map[Type]float64{PullRequest: 2},
+		States: map[string]float64{"merged": 3},
+	}
+
+	tests := []struct {
+		name    string
+		weights ScoreWeights
+		c       Contribution
+		want    float64
+	}{
+		{
+			name:    "open issue without repo info",
+			weights: defaults,
+			c:       Contribution{Type: Issue},
+			want:    1,
+		},
+		{
+			name:    "merged PR in a popular repo",
+			weights: defaults,
+			c:       Contribution{Type: PullRequest, Merged: true, Closed: true, RepoInfo: &RepoInfo{Stars: 20000}, LinesChanged: lines(300)},
+			// 3 × 2 × 3 × 2
+			want: 36,
+		},
+		{
+			name:    "closed PR of a few lines",
+			weights: defaults,
+			c:       Contribution{Type: PullRequest, Closed: true, RepoInfo: &RepoInfo{Stars: 150}, LinesChanged: lines(5)},
+			// 3 × 0.5 × 1.5 × 0.5
+			want: 1.125,
+		},
+		{
+			name:    "commit without a state",
+			weights: defaults,
+			c:       Contribution{Type: Commit, LinesChanged: lines(1000)},
+			want:    2.5,
+		},
+		{
+			name:    "missing repo",
+			weights: defaults,
+			c:       Contribution{Type: Ledger, RepoInfo: &RepoInfo{Stars: 20000, Missing: true}},
+			want:    2,
+		},
+		{
+			name:    "listed weights",
+			weights: partial,
+			c:       Contribution{Type: PullRequest, Merged: true, Closed: true},
+			want:    6,
+		},
+		{
+			// unlisted types, states, tiers and sizes weigh 1
+			name:    "unlisted weights",
+			weights: partial,
+			c:       Contribution{Type: Review, RepoInfo: &RepoInfo{Stars: 5}, LinesChanged: lines(20)},
+			want:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.weights.Score(tt.c); got != tt.want {
+				t.Errorf("Score() = %v, want %v (factors %+v)", got, tt.want, tt.weights.Explain(tt.c))
+			}
+		})
+	}
+}
+
+func TestScoreWeightsWith(t *testing.T) {
+	w := DefaultScoreWeights().With(ScoreWeights{Types: map[Type]float64{Issue: 5}})
+	if w.Types[Issue] != 5 || w.Types[PullRequest] != 3 {
+		t.Errorf("With() types = %v, want issue 5 and the default pr 3", w.Types)
+	}
+	if DefaultScoreWeights().Types[Issue] != 1 {
+		t.Error("With() changed the defaults")
+	}
+}
+
+func TestScoreWeightsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		w       ScoreWeights
+		wantErr bool
+	}{
+		{name: "defaults", w: DefaultScoreWeights()},
+		{name: "unknown tier", w: ScoreWeights{Stars: map[string]float64{"1-10": 1}}, wantErr: true},
+		{name: "unknown size", w: ScoreWeights{Sizes: map[string]float64{"-": 1, "xxl": 3}}, wantErr: true},
+		{name: "negative", w: ScoreWeights{States: map[string]float64{"open": -1}}, wantErr: true},
+		// mis-cased keys would never be looked up
+		{name: "mis-cased state", w: ScoreWeights{States: map[string]float64{"Merged": 5}}, wantErr: true},
+		{name: "mis-cased type", w: ScoreWeights{Types: map[Type]float64{"PR": 5}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := tt.w.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
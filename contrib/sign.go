@@ -0,0 +1,102 @@
+package contrib
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+)
+
+// SummaryGroups are the groups whose summaries are embedded in signed
+// reports.
+var SummaryGroups = []string{"kind", "repo", "year"}
+
+// WithSummaries returns the report with the summaries of its items, so that
+// readers of a signed report can check the numbers against the items.
+func (r Report) WithSummaries() Report {
+	r.Summaries = make(map[string][]Summary, len(SummaryGroups))
+	for _, g := range SummaryGroups {
+		// the groups are known
+		r.Summaries[g], _ = SummarizeBy(r.Items, g)
+	}
+	return r
+}
+
+// CheckSummaries recomputes the embedded summaries from the items and
+// returns an error listing every row which differs.
+func (r Report) CheckSummaries() error {
+	if len(r.Summaries) == 0 {
+		return fmt.Errorf("the report has no summaries")
+	}
+	var problems []string
+	for group, embedded := range r.Summaries {
+		computed, err := SummarizeBy(r.Items, group)
+		if err != nil {
+			return err
+		}
+		want := make(map[string]Summary, len(computed))
+		for _, s := range computed {
+			want[s.Key] = s
+		}
+		for _, s := range embedded {
+			w, ok := want[s.Key]
+			delete(want, s.Key)
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("%s %s: no items", group, s.Key))
+			case !sameCounts(s, w):
+				problems = append(problems, fmt.Sprintf("%s %s: %s, the items give %s", group, s.Key, countsString(s), countsString(w)))
+			}
+		}
+		for k, w := range want {
+			problems = append(problems, fmt.Sprintf("%s %s: missing, the items give %s", group, k, countsString(w)))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("the summaries do not match the items:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func sameCounts(a, b Summary) bool {
+	return a.Issues == b.Issues && a.PRs == b.PRs && a.Commits == b.Commits && a.Others == b.Others &&
+		a.IssuePercent == b.IssuePercent && a.PRPercent == b.PRPercent &&
+		a.Score == b.Score && a.IssueScorePercent == b.IssueScorePercent && a.PRScorePercent == b.PRScorePercent &&
+		a.TimeToMerge.Count == b.TimeToMerge.Count &&
+		a.TimeToClose.Count == b.TimeToClose.Count &&
+		a.TimeToFirstResponse.Count == b.TimeToFirstResponse.Count
+}
+
+func countsString(s Summary) string {
+	counts := fmt.Sprintf("%d issues, %d PRs, %d commits, %d others", s.Issues, s.PRs, s.Commits, s.Others)
+	if s.Score != 0 {
+		counts += fmt.Sprintf(", score %s", strconv.FormatFloat(s.Score, 'f', -1, 64))
+	}
+	return counts
+}
+
+// SignDetached writes an armored detached OpenPGP signature of the report as
+// written by WriteJSON. The private key of signer has to be decrypted.
+func SignDetached(w io.Writer, report []byte, signer *openpgp.Entity) error {
+	return openpgp.ArmoredDetachSign(w, signer, bytes.NewReader(report), nil)
+}
+
+// VerifyDetached checks an armored or binary detached signature of the
+// report against the keyring, and returns the signer and the decoded report.
+func VerifyDetached(report, signature []byte, keyring openpgp.KeyRing) (*openpgp.Entity, Report, error) {
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(report), bytes.NewReader(signature))
+	if err != nil && bytes.HasPrefix(bytes.TrimSpace(signature), []byte("-----BEGIN")) {
+		return nil, Report{}, fmt.Errorf("bad signature: %w", err)
+	}
+	if err != nil {
+		signer, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(report), bytes.NewReader(signature))
+		if err != nil {
+			return nil, Report{}, fmt.Errorf("bad signature: %w", err)
+		}
+	}
+	r, err := ReadReport(bytes.NewReader(report))
+	return signer, r, err
+}
@@ -0,0 +1,81 @@
+package contrib
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckSummaries(t *testing.T) {
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	items := []Contribution{
+		{Type: PullRequest, Number: 1, Repo: "a/a", CreatedAt: at, Kind: "fix"},
+		{Type: Issue, Number: 2, Repo: "b/b", CreatedAt: at},
+	}
+	signed := Report{Items: items}.WithSummaries()
+
+	tests := []struct {
+		name    string
+		report  func() Report
+		wantErr string
+	}{
+		{
+			name:   "matching",
+			report: func() Report { return signed },
+		},
+		{
+			name:    "no summaries",
+			report:  func() Report { return Report{Items: items} },
+			wantErr: "no summaries",
+		},
+		{
+			name: "item added",
+			report: func() Report {
+				r := signed
+				r.Items = append(append([]Contribution{}, items...), Contribution{Type: PullRequest, Number: 3, Repo: "a/a", CreatedAt: at})
+				return r
+			},
+			wantErr: "repo a/a: 0 issues, 1 PRs, 0 commits, 0 others, the items give 0 issues, 2 PRs",
+		},
+		{
+			name: "item removed",
+			report: func() Report {
+				r := signed
+				r.Items = items[:1]
+				return r
+			},
+			wantErr: "repo b/b: no items",
+		},
+		{
+			name: "score changed",
+			report: func() Report {
+				r := Report{Items: append([]Contribution{}, items...)}
+				r.Items[0].Score = 2
+				r = r.WithSummaries()
+				r.Items = items
+				return r
+			},
+			wantErr: "repo a/a: 0 issues, 1 PRs, 0 commits, 0 others, score 2, the items give 0 issues, 1 PRs, 0 commits, 0 others",
+		},
+		{
+			name: "row removed",
+			report: func() Report {
+				r := signed
+				r.Summaries = map[string][]Summary{"repo": signed.Summaries["repo"][:1]}
+				return r
+			},
+			wantErr: "repo b/b: missing, the items give 1 issues",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.report().CheckSummaries()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("CheckSummaries() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("CheckSummaries() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package contrib
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Summary holds the contribution counts of one group, e.g. a year or a
+// repository.
+type Summary struct {
+	Key     string `json:"key"`
+	Issues  int    `json:"issues"`
+	PRs     int    `json:"prs"`
+	Commits int    `json:"commits"`
+	Others  int    `json:"others"`
+
+	// IssuePercent and PRPercent are the group's share of all issues and
+	// pull requests.
+	IssuePercent float64 `json:"issue_percent"`
+	PRPercent    float64 `json:"pr_percent"`
+
+	// Score is the sum of the scores of the group, IssueScorePercent and
+	// PRScorePercent are its share of the scores of all issues and pull
+	// requests.
+	Score             float64 `json:"score"`
+	IssueScorePercent float64 `json:"issue_score_percent"`
+	PRScorePercent    float64 `json:"pr_score_percent"`
+
+	// TimeToMerge is the time to merge pull requests, TimeToClose the time
+	// to close pull requests without merging and TimeToFirstResponse the
+	// time until a maintainer first responded to an issue or pull request.
+	TimeToMerge         Latency `json:"time_to_merge"`
+	TimeToClose         Latency `json:"time_to_close"`
+	TimeToFirstResponse Latency `json:"time_to_first_response"`
+}
+
+// KeyFunc returns the group a contribution is counted in.
+type KeyFunc func(Contribution) string
+
+// Grouping keys for Summarize.
+var (
+	ByYear KeyFunc = func(c Contribution) string { return c.Year() }
+	ByRepo KeyFunc = func(c Contribution) string { return c.Repo }
+	ByKind KeyFunc = func(c Contribution) string { return kindString(c.Kind) }
+
+	// Grouping keys by the metadata of the repo, "-" if it is unknown.
+	ByStars   KeyFunc = starTier
+	ByLicense KeyFunc = func(c Contribution) string {
+		return repoInfoString(c, func(i RepoInfo) string { return i.License })
+	}
+	ByArchived KeyFunc = func(c Contribution) string {
+		return repoInfoString(c, func(i RepoInfo) string { return yesNo(i.Archived) })
+	}
+	ByFork KeyFunc = func(c Contribution) string {
+		return repoInfoString(c, func(i RepoInfo) string { return yesNo(i.Fork) })
+	}
+
+	// ByFoundation and ByMaturity group by the catalog, "-" if unmatched.
+	ByFoundation KeyFunc = func(c Contribution) string { return dashIfEmpty(c.Foundation) }
+	ByMaturity   KeyFunc = func(c Contribution) string { return dashIfEmpty(c.Maturity) }
+)
+
+// Groups maps the names accepted by GroupBy to their key functions.
+var Groups = map[string]KeyFunc{
+	"year":     ByYear,
+	"repo":     ByRepo,
+	"kind":     ByKind,
+	"stars":    ByStars,
+	"license":  ByLicense,
+	"archived": ByArchived,
+	"fork":     ByFork,
+
+	"foundation": ByFoundation,
+	"maturity":   ByMaturity,
+}
+
+// GroupNames returns the names of Groups in a stable order.
+func GroupNames() []string {
+	var names []string
+	for k := range Groups {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Summarize counts the contributions per key. The result is sorted by key.
+func Summarize(contributions []Contribution, key KeyFunc) []Summary {
+	m := make(map[string]*Summary)
+	l := make(map[string]*latencies)
+	issueScores := make(map[string]float64)
+	prScores := make(map[string]float64)
+	var totalIssues, totalPRs int
+	var totalIssueScore, totalPRScore float64
+	for _, c := range contributions {
+		k := key(c)
+		s, ok := m[k]
+		if !ok {
+			s = &Summary{Key: k}
+			m[k] = s
+			l[k] = &latencies{}
+		}
+		l[k].add(c)
+		s.Score += c.Score
+		switch c.Type {
+		case Issue:
+			s.Issues++
+			totalIssues++
+			issueScores[k] += c.Score
+			totalIssueScore += c.Score
+		case PullRequest:
+			s.PRs++
+			totalPRs++
+			prScores[k] += c.Score
+			totalPRScore += c.Score
+		case Commit:
+			s.Commits++
+		default:
+			s.Others++
+		}
+	}
+
+	summaries := make([]Summary, 0, len(m))
+	for _, s := range m {
+		s.IssuePercent = ratio(float64(s.Issues), float64(totalIssues))
+		s.PRPercent = ratio(float64(s.PRs), float64(totalPRs))
+		s.IssueScorePercent = ratio(issueScores[s.Key], totalIssueScore)
+		s.PRScorePercent = ratio(prScores[s.Key], totalPRScore)
+		l[s.Key].set(s)
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Key < summaries[j].Key
+	})
+	return summaries
+}
+
+// SummarizeByYear counts the contributions per year, including the years
+// without any contribution between the first and the last one.
+func SummarizeByYear(contributions []Contribution) []Summary {
+	summaries := Summarize(contributions, ByYear)
+	if len(summaries) == 0 {
+		return summaries
+	}
+
+	// filling no data year
+	startYear, _ := strconv.Atoi(summaries[0].Key)
+	endYear, _ := strconv.Atoi(summaries[len(summaries)-1].Key)
+	filled := make([]Summary, 0, endYear-startYear+1)
+	i := 0
+	for y := startYear; y <= endYear; y++ {
+		if i < len(summaries) && summaries[i].Key == strconv.Itoa(y) {
+			filled = append(filled, summaries[i])
+			i++
+			continue
+		}
+		filled = append(filled, Summary{Key: strconv.Itoa(y)})
+	}
+	return filled
+}
+
+// SummarizeBy summarizes by the named group, see Groups.
+func SummarizeBy(contributions []Contribution, group string) ([]Summary, error) {
+	key, ok := Groups[group]
+	if !ok {
+		return nil, fmt.Errorf("unknown group: %s (valid: %s)", group, strings.Join(GroupNames(), ", "))
+	}
+	if group == "year" {
+		return SummarizeByYear(contributions), nil
+	}
+	return Summarize(contributions, key), nil
+}
+
+// ratio returns n/total, or 0 if total is 0.
+func ratio(n, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return n / total
+}
@@ -0,0 +1,72 @@
+package contrib
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	at := func(year int) time.Time { return time.Date(year, 3, 1, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name          string
+		contributions []Contribution
+		key           KeyFunc
+		want          []Summary
+	}{
+		{
+			name: "empty",
+			key:  ByRepo,
+			want: []Summary{},
+		},
+		{
+			name: "counts and shares per repo",
+			contributions: []Contribution{
+				{Type: PullRequest, Repo: "b/b", CreatedAt: at(2020), Score: 3},
+				{Type: Issue, Repo: "a/a", CreatedAt: at(2020), Score: 1},
+				{Type: PullRequest, Repo: "a/a", CreatedAt: at(2021), Score: 1},
+				{Type: Commit, Repo: "a/a", CreatedAt: at(2021), Score: 1},
+				{Type: Ledger, Repo: "a/a", CreatedAt: at(2021), Score: 2},
+			},
+			key: ByRepo,
+			want: []Summary{
+				{Key: "a/a", Issues: 1, PRs: 1, Commits: 1, Others: 1, IssuePercent: 1, PRPercent: 0.5, Score: 5, IssueScorePercent: 1, PRScorePercent: 0.25},
+				{Key: "b/b", PRs: 1, PRPercent: 0.5, Score: 3, PRScorePercent: 0.75},
+			},
+		},
+		{
+			name: "reviews count as others",
+			contributions: []Contribution{
+				{Type: Review, Repo: "a/a", CreatedAt: at(2021)},
+				{Type: Review, Repo: "a/a", CreatedAt: at(2022)},
+			},
+			key: ByYear,
+			want: []Summary{
+				{Key: "2021", Others: 1},
+				{Key: "2022", Others: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summarize(tt.contributions, tt.key)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Summarize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeByYear(t *testing.T) {
+	contributions := []Contribution{
+		{Type: Issue, CreatedAt: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Type: Issue, CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	var keys []string
+	for _, s := range SummarizeByYear(contributions) {
+		keys = append(keys, s.Key)
+	}
+	if want := []string{"2019", "2020", "2021"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("SummarizeByYear() keys = %v, want %v", keys, want)
+	}
+}
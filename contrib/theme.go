@@ -0,0 +1,56 @@
+package contrib
+
+import (
+	"fmt"
+
+	"github.com/muesli/termenv"
+)
+
+type Theme struct {
+	Red     termenv.Color
+	Yellow  termenv.Color
+	Green   termenv.Color
+	Blue    termenv.Color
+	Gray    termenv.Color
+	Magenta termenv.Color
+	Cyan    termenv.Color
+}
+
+// DefaultThemeName returns the theme matching the terminal background.
+func DefaultThemeName() string {
+	if !termenv.HasDarkBackground() {
+		return "light"
+	}
+	return "dark"
+}
+
+// LoadTheme returns the named theme with colors converted to the profile.
+func LoadTheme(theme string, p termenv.Profile) (Theme, error) {
+	themes := make(map[string]Theme)
+
+	themes["dark"] = Theme{
+		Red:     p.Color("#E88388"),
+		Yellow:  p.Color("#DBAB79"),
+		Green:   p.Color("#A8CC8C"),
+		Blue:    p.Color("#71BEF2"),
+		Gray:    p.Color("#B9BFCA"),
+		Magenta: p.Color("#D290E4"),
+		Cyan:    p.Color("#66C2CD"),
+	}
+
+	themes["light"] = Theme{
+		Red:     p.Color("#D70000"),
+		Yellow:  p.Color("#FFAF00"),
+		Green:   p.Color("#005F00"),
+		Blue:    p.Color("#000087"),
+		Gray:    p.Color("#303030"),
+		Magenta: p.Color("#AF00FF"),
+		Cyan:    p.Color("#0087FF"),
+	}
+
+	if _, ok := themes[theme]; !ok {
+		return Theme{}, fmt.Errorf("Unknown theme: %s", theme)
+	}
+
+	return themes[theme], nil
+}
@@ -0,0 +1,150 @@
+package contrib
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Latency is the median and the 90th percentile of a set of durations.
+type Latency struct {
+	Count  int
+	Median time.Duration
+	P90    time.Duration
+}
+
+// MarshalJSON writes the durations in seconds.
+func (l Latency) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Count  int     `json:"count"`
+		Median float64 `json:"median_seconds"`
+		P90    float64 `json:"p90_seconds"`
+	}{l.Count, l.Median.Seconds(), l.P90.Seconds()})
+}
+
+// UnmarshalJSON reads what MarshalJSON writes.
+func (l *Latency) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Count  int     `json:"count"`
+		Median float64 `json:"median_seconds"`
+		P90    float64 `json:"p90_seconds"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	l.Count = v.Count
+	l.Median = time.Duration(v.Median * float64(time.Second))
+	l.P90 = time.Duration(v.P90 * float64(time.Second))
+	return nil
+}
+
+// newLatency returns the nearest-rank median and 90th percentile of d.
+func newLatency(d []time.Duration) Latency {
+	if len(d) == 0 {
+		return Latency{}
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+	return Latency{Count: len(d), Median: percentile(d, 50), P90: percentile(d, 90)}
+}
+
+// percentile returns the p-th percentile of the sorted durations d by the
+// nearest-rank method.
+func percentile(d []time.Duration, p int) time.Duration {
+	rank := (p*len(d) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return d[rank-1]
+}
+
+// TimeToMerge returns the time from opening to merging a pull request.
+func (c Contribution) TimeToMerge() (time.Duration, bool) {
+	if c.Type != PullRequest || !c.Merged || c.MergedAt == nil {
+		return 0, false
+	}
+	return c.MergedAt.Sub(c.CreatedAt), true
+}
+
+// TimeToClose returns the time from opening to closing a pull request
+// which was not merged.
+func (c Contribution) TimeToClose() (time.Duration, bool) {
+	if c.Type != PullRequest || c.Merged || c.ClosedAt == nil {
+		return 0, false
+	}
+	return c.ClosedAt.Sub(c.CreatedAt), true
+}
+
+// TimeToFirstResponse returns the time from opening an issue or a pull
+// request to the first maintainer response.
+func (c Contribution) TimeToFirstResponse() (time.Duration, bool) {
+	if (c.Type != Issue && c.Type != PullRequest) || c.FirstResponseAt == nil {
+		return 0, false
+	}
+	return c.FirstResponseAt.Sub(c.CreatedAt), true
+}
+
+// latencies collects the durations of one summary group.
+type latencies struct {
+	merge, close, response []time.Duration
+}
+
+func (l *latencies) add(c Contribution) {
+	if d, ok := c.TimeToMerge(); ok {
+		l.merge = append(l.merge, d)
+	}
+	if d, ok := c.TimeToClose(); ok {
+		l.close = append(l.close, d)
+	}
+	if d, ok := c.TimeToFirstResponse(); ok {
+		l.response = append(l.response, d)
+	}
+}
+
+func (l *latencies) set(s *Summary) {
+	s.TimeToMerge = newLatency(l.merge)
+	s.TimeToClose = newLatency(l.close)
+	s.TimeToFirstResponse = newLatency(l.response)
+}
+
+// SlowestRepos returns the summaries of the n repositories with the longest
+// median time to merge, slowest first.
+func SlowestRepos(contributions []Contribution, n int) []Summary {
+	var slowest []Summary
+	for _, s := range Summarize(contributions, ByRepo) {
+		if s.TimeToMerge.Count > 0 {
+			slowest = append(slowest, s)
+		}
+	}
+	sort.SliceStable(slowest, func(i, j int) bool {
+		return slowest[i].TimeToMerge.Median > slowest[j].TimeToMerge.Median
+	})
+	if n > 0 && len(slowest) > n {
+		slowest = slowest[:n]
+	}
+	return slowest
+}
+
+// latencyValue returns the raw column value of a duration, -1 if there is
+// no data so that it sorts first.
+func latencyValue(l Latency, d time.Duration) interface{} {
+	if l.Count == 0 {
+		return time.Duration(-1)
+	}
+	return d
+}
+
+// durationString renders a duration in minutes, hours or days.
+func durationString(_ TableRenderer, v interface{}) string {
+	d := v.(time.Duration)
+	switch {
+	case d < 0:
+		return "-"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+}
@@ -0,0 +1,46 @@
+package contrib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	hours := func(n int) []time.Duration {
+		d := make([]time.Duration, n)
+		for i := range d {
+			d[i] = time.Duration(i+1) * time.Hour
+		}
+		return d
+	}
+	tests := []struct {
+		d    []time.Duration
+		p    int
+		want time.Duration
+	}{
+		{d: hours(1), p: 50, want: 1 * time.Hour},
+		{d: hours(1), p: 90, want: 1 * time.Hour},
+		{d: hours(4), p: 50, want: 2 * time.Hour},
+		{d: hours(5), p: 50, want: 3 * time.Hour},
+		{d: hours(4), p: 90, want: 4 * time.Hour},
+		{d: hours(10), p: 90, want: 9 * time.Hour},
+		{d: hours(10), p: 0, want: 1 * time.Hour},
+		{d: hours(10), p: 100, want: 10 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.d, tt.p); got != tt.want {
+			t.Errorf("percentile(%d durations, %d) = %s, want %s", len(tt.d), tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestNewLatency(t *testing.T) {
+	got := newLatency([]time.Duration{3 * time.Hour, time.Hour, 2 * time.Hour})
+	want := Latency{Count: 3, Median: 2 * time.Hour, P90: 3 * time.Hour}
+	if got != want {
+		t.Errorf("newLatency() = %+v, want %+v", got, want)
+	}
+	if got := newLatency(nil); got != (Latency{}) {
+		t.Errorf("newLatency(nil) = %+v, want zero", got)
+	}
+}